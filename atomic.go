@@ -0,0 +1,113 @@
+package gomap
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// mapSeq numbers the Maps in the order of creation, which is the canonical order of locking several Maps.
+var mapSeq atomic.Uint64
+
+// Lockable is the Map of any type, which can be locked together with the others by LockAll or Atomically.
+type Lockable interface {
+	lockSeq() uint64
+	lock()
+	unlock()
+}
+
+func (m Map[K, V]) lockSeq() uint64 { return m.seq }
+func (m Map[K, V]) lock()           { m.mutex.Lock() }
+func (m Map[K, V]) unlock()         { m.mutex.Unlock() }
+
+// Atomically takes the write locks of all the maps by LockAll and runs fn holding them.
+// It's meant for the invariants spanning several Maps, e.g. the forward and the reverse indexes.
+// The methods of the locked Maps must not be called by fn, it accesses them by Map.Locked instead.
+func Atomically(fn func() error, maps ...Lockable) error {
+	LockAll(maps...)
+	defer UnlockAll(maps...)
+
+	return fn()
+}
+
+// LockAll takes the write locks of the distinct maps in the canonical order of their creation,
+// so the goroutines locking the same Maps listed in the different order never deadlock.
+// The same Map listed twice is locked once. The locks are released by UnlockAll with the same maps.
+func LockAll(maps ...Lockable) {
+	for _, m := range canonical(maps) {
+		m.lock()
+	}
+}
+
+// UnlockAll releases the write locks taken by LockAll in the reverse order.
+func UnlockAll(maps ...Lockable) {
+	ordered := canonical(maps)
+	for i := len(ordered) - 1; i >= 0; i-- {
+		ordered[i].unlock()
+	}
+}
+
+// canonical return the distinct maps in the order of their creation.
+func canonical(maps []Lockable) []Lockable {
+	ordered := make([]Lockable, 0, len(maps))
+	seen := make(map[uint64]struct{}, len(maps))
+
+	for _, m := range maps {
+		if _, ok := seen[m.lockSeq()]; !ok {
+			seen[m.lockSeq()] = struct{}{}
+			ordered = append(ordered, m)
+		}
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].lockSeq() < ordered[j].lockSeq()
+	})
+
+	return ordered
+}
+
+// LockedMap is the view of Map[K, V] accessing it without locking, valid only while its lock is held by Atomically or LockAll.
+// The configured options are applied as by the Map methods.
+type LockedMap[K comparable, V any] struct {
+	m Map[K, V]
+}
+
+// Locked return the view of Map[K, V] for the use within Atomically.
+func (m Map[K, V]) Locked() LockedMap[K, V] {
+	return LockedMap[K, V]{m: m}
+}
+
+// Get return the value by key.
+func (l LockedMap[K, V]) Get(k K) (V, bool) {
+	v, exists := l.m.innerMap[l.m.key(k)]
+
+	return v, exists
+}
+
+// Exists check if value by key exists.
+func (l LockedMap[K, V]) Exists(k K) bool {
+	_, exists := l.m.innerMap[l.m.key(k)]
+
+	return exists
+}
+
+// Add adds the element and return ErrQuotaExceeded if the quota rejects it.
+func (l LockedMap[K, V]) Add(k K, v V) error {
+	return l.m.store(k, v)
+}
+
+// Delete deletes the element by key.
+func (l LockedMap[K, V]) Delete(k K) bool {
+	k = l.m.key(k)
+
+	if _, exists := l.m.innerMap[k]; exists {
+		l.m.remove(k)
+		return true
+	}
+
+	return false
+}
+
+// Len return the number of elements.
+func (l LockedMap[K, V]) Len() int {
+	return len(l.m.innerMap)
+}
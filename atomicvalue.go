@@ -0,0 +1,135 @@
+package gomap
+
+import "sync/atomic"
+
+// AtomicSlot is the value of AtomicValueMap, which is loaded and updated atomically without any lock.
+type AtomicSlot[V any] struct {
+	p atomic.Pointer[V]
+}
+
+// Load return the value of the slot, false is returned if it was never stored.
+func (s *AtomicSlot[V]) Load() (V, bool) {
+	if p := s.p.Load(); p != nil {
+		return *p, true
+	}
+
+	var v V
+	return v, false
+}
+
+// Store stores the value in the slot.
+func (s *AtomicSlot[V]) Store(v V) {
+	s.p.Store(&v)
+}
+
+// Swap stores the value in the slot and return the previous one.
+func (s *AtomicSlot[V]) Swap(v V) (previous V, loaded bool) {
+	if p := s.p.Swap(&v); p != nil {
+		return *p, true
+	}
+
+	return previous, false
+}
+
+// CompareAndSwap stores the new value if the current one is equal to old.
+// Like Map.CompareAndSwap, it panics if the values are not comparable, use Update for such V.
+func (s *AtomicSlot[V]) CompareAndSwap(old, new V) bool {
+	p := s.p.Load()
+
+	for p != nil && equalAny(*p, old) {
+		if s.p.CompareAndSwap(p, &new) {
+			return true
+		}
+
+		p = s.p.Load()
+	}
+
+	return false
+}
+
+// Update applies fn to the current value and its existence flag and stores the result, retrying if the slot
+// is changed concurrently, so fn may be called several times and must not have side effects.
+func (s *AtomicSlot[V]) Update(fn func(V, bool) V) V {
+	for {
+		p := s.p.Load()
+
+		var current V
+		if p != nil {
+			current = *p
+		}
+
+		next := fn(current, p != nil)
+		if s.p.CompareAndSwap(p, &next) {
+			return next
+		}
+	}
+}
+
+// AtomicValueMap is a concurrency safe map of the AtomicSlot values. The map lock is taken only to find
+// or to create the slot of the key, the values are updated atomically, so the per key state changing often
+// over the stable set of keys does not contend on the map lock. The slot returned by Slot can be kept
+// to skip the map lookup at all.
+type AtomicValueMap[K comparable, V any] struct {
+	slots Map[K, *AtomicSlot[V]]
+}
+
+// NewAtomicValueMap creates the empty AtomicValueMap[K, V].
+func NewAtomicValueMap[K comparable, V any]() AtomicValueMap[K, V] {
+	return AtomicValueMap[K, V]{slots: From(map[K]*AtomicSlot[V]{})}
+}
+
+// Slot return the slot of the key, creating the empty one if the key does not exist.
+// The slot deleted from the map is detached, its further updates are not visible in the map.
+func (a AtomicValueMap[K, V]) Slot(k K) *AtomicSlot[V] {
+	if slot, exists := a.slots.Get(k); exists {
+		return slot
+	}
+
+	return a.slots.GetOrCompute(k, func() *AtomicSlot[V] {
+		return &AtomicSlot[V]{}
+	})
+}
+
+// Load return the value by key.
+func (a AtomicValueMap[K, V]) Load(k K) (V, bool) {
+	if slot, exists := a.slots.Get(k); exists {
+		return slot.Load()
+	}
+
+	var v V
+	return v, false
+}
+
+// Store stores the value by key.
+func (a AtomicValueMap[K, V]) Store(k K, v V) {
+	a.Slot(k).Store(v)
+}
+
+// Swap stores the value by key and return the previous one.
+func (a AtomicValueMap[K, V]) Swap(k K, v V) (V, bool) {
+	return a.Slot(k).Swap(v)
+}
+
+// CompareAndSwap stores the new value by key if the current one is equal to old.
+func (a AtomicValueMap[K, V]) CompareAndSwap(k K, old, new V) bool {
+	if slot, exists := a.slots.Get(k); exists {
+		return slot.CompareAndSwap(old, new)
+	}
+
+	return false
+}
+
+// Update applies fn to the current value by key, see AtomicSlot.Update.
+func (a AtomicValueMap[K, V]) Update(k K, fn func(V, bool) V) V {
+	return a.Slot(k).Update(fn)
+}
+
+// Delete deletes the key and its slot.
+func (a AtomicValueMap[K, V]) Delete(k K) bool {
+	return a.slots.Delete(k)
+}
+
+// Len return the number of keys, taking the read lock of the map, so it's safe alongside Store of the new keys.
+func (a AtomicValueMap[K, V]) Len() int {
+	return a.slots.Len()
+}
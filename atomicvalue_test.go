@@ -0,0 +1,67 @@
+package gomap_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestAtomicValueMap(t *testing.T) {
+	m := gomap.NewAtomicValueMap[string, int]()
+
+	m.Store("a", 1)
+	v, ok := m.Load("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	previous, loaded := m.Swap("a", 2)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, previous)
+
+	assert.True(t, m.CompareAndSwap("a", 2, 3))
+	assert.False(t, m.CompareAndSwap("a", 2, 4))
+	assert.False(t, m.CompareAndSwap("missing", 0, 1))
+
+	assert.Equal(t, 4, m.Update("a", func(v int, exists bool) int { return v + 1 }))
+
+	assert.True(t, m.Delete("a"))
+	_, ok = m.Load("a")
+	assert.False(t, ok)
+}
+
+func TestAtomicValueMapDetachedSlot(t *testing.T) {
+	m := gomap.NewAtomicValueMap[string, int]()
+
+	slot := m.Slot("a")
+	m.Delete("a")
+	slot.Store(1)
+
+	_, ok := m.Load("a")
+	assert.False(t, ok)
+}
+
+func TestAtomicValueMapConcurrentUpdates(t *testing.T) {
+	m := gomap.NewAtomicValueMap[int, int]()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 100; j++ {
+				m.Update(0, func(v int, _ bool) int { return v + 1 })
+				m.Store(i*100+j+1, j)
+				_ = m.Len()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	v, _ := m.Load(0)
+	assert.Equal(t, 800, v)
+	assert.Equal(t, 801, m.Len())
+}
@@ -0,0 +1,134 @@
+package gomap
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BiMap is a concurrency safe one-to-one map, which keeps the forward and the inverse indexes consistent,
+// so the key can be found by value as fast as the value by key.
+type BiMap[K, V comparable] struct {
+	mutex   *sync.RWMutex
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates the empty BiMap[K, V].
+func NewBiMap[K, V comparable]() BiMap[K, V] {
+	return BiMap[K, V]{
+		mutex:   &sync.RWMutex{},
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// Add binds the key and the value. The previous value of the key and the previous key of the value are unbound,
+// so the both indexes stay one-to-one.
+func (b BiMap[K, V]) Add(k K, v V) BiMap[K, V] {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.unbind(k)
+
+	if previous, exists := b.inverse[v]; exists {
+		delete(b.forward, previous)
+	}
+
+	b.forward[k] = v
+	b.inverse[v] = k
+
+	return b
+}
+
+// TryAdd binds the key and the value only if neither of them is bound yet, otherwise ErrKeyExists is returned.
+func (b BiMap[K, V]) TryAdd(k K, v V) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, exists := b.forward[k]; exists {
+		return fmt.Errorf("add %v: %w", k, ErrKeyExists)
+	}
+
+	if previous, exists := b.inverse[v]; exists {
+		return fmt.Errorf("add %v: value %v is bound to %v: %w", k, v, previous, ErrKeyExists)
+	}
+
+	b.forward[k] = v
+	b.inverse[v] = k
+
+	return nil
+}
+
+// Get return the value by key.
+func (b BiMap[K, V]) Get(k K) (V, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	v, exists := b.forward[k]
+
+	return v, exists
+}
+
+// GetByValue return the key by value.
+func (b BiMap[K, V]) GetByValue(v V) (K, bool) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	k, exists := b.inverse[v]
+
+	return k, exists
+}
+
+// Delete deletes the key and its value.
+func (b BiMap[K, V]) Delete(k K) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.unbind(k)
+}
+
+// DeleteByValue deletes the value and its key.
+func (b BiMap[K, V]) DeleteByValue(v V) bool {
+	return b.Inverse().Delete(v)
+}
+
+// Len return the number of the bound pairs.
+func (b BiMap[K, V]) Len() int {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return len(b.forward)
+}
+
+// Inverse return the BiMap[V, K] sharing the state with BiMap[K, V], the changes of either are visible in both.
+func (b BiMap[K, V]) Inverse() BiMap[V, K] {
+	return BiMap[V, K]{
+		mutex:   b.mutex,
+		forward: b.inverse,
+		inverse: b.forward,
+	}
+}
+
+// Map return the copy of the forward index as Map[K, V].
+func (b BiMap[K, V]) Map() Map[K, V] {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	copied := make(map[K]V, len(b.forward))
+	for k, v := range b.forward {
+		copied[k] = v
+	}
+
+	return newMap(copied)
+}
+
+// unbind deletes the key from both indexes. The write lock must be held.
+func (b BiMap[K, V]) unbind(k K) bool {
+	v, exists := b.forward[k]
+	if exists {
+		delete(b.forward, k)
+		delete(b.inverse, v)
+	}
+
+	return exists
+}
@@ -0,0 +1,67 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestBiMap(t *testing.T) {
+	b := gomap.NewBiMap[string, int]()
+	b.Add("a", 1).Add("b", 2)
+
+	v, ok := b.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	k, ok := b.GetByValue(2)
+	assert.True(t, ok)
+	assert.Equal(t, "b", k)
+
+	assert.True(t, b.DeleteByValue(1))
+	assert.False(t, b.Delete("a"))
+	assert.Equal(t, 1, b.Len())
+}
+
+func TestBiMapAddUnbindsPreviousPairs(t *testing.T) {
+	b := gomap.NewBiMap[string, int]()
+	b.Add("a", 1).Add("b", 2)
+
+	// "a" takes the value of "b", so both of their previous bindings are gone.
+	b.Add("a", 2)
+
+	assert.Equal(t, 1, b.Len())
+	assert.False(t, b.Inverse().Map().Exists(1))
+
+	_, ok := b.Get("b")
+	assert.False(t, ok)
+
+	k, _ := b.GetByValue(2)
+	assert.Equal(t, "a", k)
+}
+
+func TestBiMapTryAdd(t *testing.T) {
+	b := gomap.NewBiMap[string, int]()
+	require.NoError(t, b.TryAdd("a", 1))
+
+	assert.ErrorIs(t, b.TryAdd("a", 2), gomap.ErrKeyExists)
+	assert.ErrorIs(t, b.TryAdd("b", 1), gomap.ErrKeyExists)
+	assert.Equal(t, map[string]int{"a": 1}, b.Map().MapCopy())
+}
+
+func TestBiMapInverseSharesState(t *testing.T) {
+	b := gomap.NewBiMap[string, int]()
+	inverse := b.Inverse()
+
+	inverse.Add(1, "a")
+
+	v, ok := b.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	b.Delete("a")
+	assert.Equal(t, 0, inverse.Len())
+}
@@ -0,0 +1,99 @@
+package gomap
+
+import (
+	"context"
+	"runtime"
+)
+
+// WithBulkChunkSize makes Clear, ReplaceAll and DeleteFunc process the elements in the chunks of the size,
+// each under its own write lock, yielding to the other goroutines in between. It keeps the cleanup of the large Map
+// from blocking the readers for long, at the cost of the atomicity: the concurrent operations may see
+// the partially processed Map, and the elements added during the bulk operation may be left unprocessed.
+// Clear takes the chunks straight from the Map, while ReplaceAll and DeleteFunc copy the keys under one read lock first,
+// which blocks the writers for the time of copying the keys, but not for the time of calling fn and pred.
+func WithBulkChunkSize[K comparable, V any](size int) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.chunkSize = size
+	}
+}
+
+// DeleteFunc deletes the elements matching the pred and return the number of deleted elements.
+// The pred is called under the write lock and must not access the Map.
+func (m Map[K, V]) DeleteFunc(pred func(K, V) bool) int {
+	if size := m.chunkSize(); size > 0 {
+		return m.deleteInBatches(context.Background(), m.AppendKeys(nil), size, pred)
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var deleted int
+	for k, v := range m.innerMap {
+		if pred(k, v) {
+			m.remove(k)
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+// clearInBatches deletes the elements taking the write lock per batch. The Map cannot be iterated across the locks,
+// so each batch is taken from the start of the new range, which skips more of the emptied slots as the Map shrinks,
+// and the deleted elements are bounded by the initial length, so the concurrent writers cannot prolong it.
+func (m Map[K, V]) clearInBatches(size int) {
+	m.mutex.RLock()
+	left := len(m.innerMap)
+	m.mutex.RUnlock()
+
+	for left > 0 {
+		n := 0
+
+		m.mutex.Lock()
+		for k := range m.innerMap {
+			if n == size || n == left {
+				break
+			}
+
+			m.remove(k)
+			n++
+		}
+		m.mutex.Unlock()
+
+		if n == 0 {
+			return
+		}
+
+		left -= n
+		runtime.Gosched()
+	}
+}
+
+// replaceInBatches replaces the values of the keys still existing, taking the write lock per batch.
+func (m Map[K, V]) replaceInBatches(keys []K, size int, fn func(K, V) V) {
+	for len(keys) > 0 {
+		n := size
+		if n > len(keys) {
+			n = len(keys)
+		}
+
+		m.mutex.Lock()
+		for _, k := range keys[:n] {
+			if v, exists := m.innerMap[k]; exists {
+				_ = m.store(k, fn(k, v))
+			}
+		}
+		m.mutex.Unlock()
+
+		keys = keys[n:]
+		runtime.Gosched()
+	}
+}
+
+func (m Map[K, V]) chunkSize() int {
+	if m.config == nil {
+		return 0
+	}
+
+	return m.config.chunkSize
+}
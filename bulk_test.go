@@ -0,0 +1,46 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func chunked(n int) gomap.Map[int, int] {
+	m := gomap.New(gomap.WithBulkChunkSize[int, int](7))
+	for i := 0; i < n; i++ {
+		m.Add(i, i)
+	}
+
+	return m
+}
+
+func TestClearInChunks(t *testing.T) {
+	m := chunked(100)
+	m.Clear()
+
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestDeleteFuncInChunks(t *testing.T) {
+	m := chunked(100)
+
+	deleted := m.DeleteFunc(func(k, _ int) bool { return k%2 == 0 })
+
+	assert.Equal(t, 50, deleted)
+	assert.Equal(t, 50, m.Len())
+	assert.False(t, m.Exists(2))
+	assert.True(t, m.Exists(3))
+}
+
+func TestReplaceAllInChunks(t *testing.T) {
+	m := chunked(100)
+
+	m.ReplaceAll(func(_, v int) int { return v * 2 })
+
+	v, _ := m.Get(21)
+	assert.Equal(t, 42, v)
+	assert.Equal(t, 100, m.Len())
+}
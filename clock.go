@@ -0,0 +1,17 @@
+package gomap
+
+import "time"
+
+// Clock provides the current time to the time based features, so the tests can control it instead of sleeping.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is the Clock backed by time.Now.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
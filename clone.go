@@ -0,0 +1,60 @@
+package gomap
+
+// Cloner return the defensive copy of the value, which the caller can mutate without affecting the stored one.
+type Cloner[V any] func(V) V
+
+// WithCloner sets the Cloner used by GetCopied, e.g. CloneSlice for the slice values.
+func WithCloner[K comparable, V any](clone Cloner[V]) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.cloner = clone
+	}
+}
+
+// GetCopied return the copy of the value by key made under the read lock, so the pointer, the slice and the map values
+// are not shared with the Map. The value is copied by the Cloner set WithCloner, otherwise by its Clone() V method.
+// The value which neither applies to is returned as is, which is only safe for V without references.
+func (m Map[K, V]) GetCopied(k K) (V, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	v, exists := m.innerMap[m.key(k)]
+	if !exists {
+		m.miss()
+		return v, false
+	}
+
+	m.hit(m.key(k))
+
+	if m.config != nil && m.config.cloner != nil {
+		return m.config.cloner(v), true
+	}
+
+	if cloner, ok := any(v).(interface{ Clone() V }); ok {
+		return cloner.Clone(), true
+	}
+
+	return v, true
+}
+
+// CloneSlice copies the slice, it can be used as the Cloner of the slice values.
+func CloneSlice[E any](s []E) []E {
+	if s == nil {
+		return nil
+	}
+
+	return append(make([]E, 0, len(s)), s...)
+}
+
+// CloneMap copies the builtin map, it can be used as the Cloner of the map values.
+func CloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+
+	copied := make(map[K]V, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+
+	return copied
+}
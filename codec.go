@@ -0,0 +1,90 @@
+package gomap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Codec encodes the values to bytes and back.
+type Codec[V any] interface {
+	Encode(V) ([]byte, error)
+	Decode([]byte) (V, error)
+}
+
+// JSONCodec is the Codec using encoding/json.
+type JSONCodec[V any] struct{}
+
+// Encode marshals v to JSON.
+func (JSONCodec[V]) Encode(v V) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Decode unmarshals v from JSON.
+func (JSONCodec[V]) Decode(b []byte) (V, error) {
+	var v V
+	err := json.Unmarshal(b, &v)
+
+	return v, err
+}
+
+// CodecMap is a concurrency safe map which keeps the values encoded by the Codec and decodes them on every Get.
+// It trades the CPU for the smaller live heap when the values are large and rarely read.
+type CodecMap[K comparable, V any] struct {
+	encoded Map[K, []byte]
+	codec   Codec[V]
+}
+
+// NewCodecMap creates the empty CodecMap[K, V] using the codec.
+func NewCodecMap[K comparable, V any](codec Codec[V]) CodecMap[K, V] {
+	return CodecMap[K, V]{
+		encoded: From(map[K][]byte{}),
+		codec:   codec,
+	}
+}
+
+// Add encodes the value and adds it to CodecMap[K, V].
+func (c CodecMap[K, V]) Add(k K, v V) error {
+	b, err := c.codec.Encode(v)
+	if err != nil {
+		return fmt.Errorf("encode %v: %w", k, err)
+	}
+
+	return c.encoded.TryAdd(k, b)
+}
+
+// Get return the decoded value by key.
+func (c CodecMap[K, V]) Get(k K) (V, bool, error) {
+	var v V
+
+	b, exists := c.encoded.Get(k)
+	if !exists {
+		return v, false, nil
+	}
+
+	v, err := c.codec.Decode(b)
+	if err != nil {
+		return v, true, fmt.Errorf("decode %v: %w", k, err)
+	}
+
+	return v, true, nil
+}
+
+// GetEncoded return the value by key as stored, without decoding.
+func (c CodecMap[K, V]) GetEncoded(k K) ([]byte, bool) {
+	return c.encoded.Get(k)
+}
+
+// Delete deletes the value by key.
+func (c CodecMap[K, V]) Delete(k K) bool {
+	return c.encoded.Delete(k)
+}
+
+// Exists check if value by key exists in CodecMap[K, V].
+func (c CodecMap[K, V]) Exists(k K) bool {
+	return c.encoded.Exists(k)
+}
+
+// Len return the number of stored values.
+func (c CodecMap[K, V]) Len() int {
+	return c.encoded.Len()
+}
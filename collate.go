@@ -0,0 +1,53 @@
+package gomap
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// WithCollation sets the comparison of the string keys used by the sorted output: SortedKeys, Dump, WriteTable
+// and WriteMarkdown, so the user-facing listings of the non-ASCII keys are sorted by the rules of the language.
+// The compare return a negative number, zero or a positive number like strings.Compare, e.g. the CompareString
+// method of golang.org/x/text/collate.Collator:
+//
+//	c := collate.New(language.German)
+//	m := gomap.New(gomap.WithCollation[string, int](c.CompareString))
+//
+// The compare is called under the lock of the option, since the collator of x/text is not safe for concurrent use.
+// The keys which are not strings are sorted naturally.
+func WithCollation[K comparable, V any](compare func(a, b string) int) Option[K, V] {
+	var mutex sync.Mutex
+
+	return func(c *config[K, V]) {
+		c.collate = func(a, b string) int {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			return compare(a, b)
+		}
+	}
+}
+
+// SortedKeys return the keys of Map[K, V] sorted naturally, or by the collation set WithCollation.
+func (m Map[K, V]) SortedKeys() []K {
+	keys := m.Keys()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return m.lessKey(keys[i], keys[j])
+	})
+
+	return keys
+}
+
+// lessKey orders the keys by the configured collation, falling back to lessAny.
+func (m Map[K, V]) lessKey(a, b K) bool {
+	if m.config != nil && m.config.collate != nil {
+		ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+		if ra.Kind() == reflect.String && rb.Kind() == reflect.String {
+			return m.config.collate(ra.String(), rb.String()) < 0
+		}
+	}
+
+	return lessAny(a, b)
+}
@@ -0,0 +1,49 @@
+package gomap_test
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestWithCollation(t *testing.T) {
+	reversed := func(a, b string) int { return strings.Compare(b, a) }
+
+	m := gomap.New(gomap.WithCollation[string, int](reversed))
+	m.Add("a", 1).Add("c", 3).Add("b", 2)
+
+	assert.Equal(t, []string{"c", "b", "a"}, m.SortedKeys())
+}
+
+func TestWithCollationSerializesCompare(t *testing.T) {
+	var active atomic.Int32
+
+	compare := func(a, b string) int {
+		if active.Add(1) > 1 {
+			t.Error("compare is called concurrently")
+		}
+		defer active.Add(-1)
+
+		return strings.Compare(a, b)
+	}
+
+	m := gomap.New(gomap.WithCollation[string, int](compare))
+	for _, k := range []string{"d", "a", "c", "b", "e"} {
+		m.Add(k, 0)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, []string{"a", "b", "c", "d", "e"}, m.SortedKeys())
+		}()
+	}
+	wg.Wait()
+}
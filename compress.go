@@ -0,0 +1,29 @@
+package gomap
+
+import (
+	"compress/gzip"
+	"io"
+)
+
+// Compression compresses the Export stream and decompresses the Import stream.
+// Other algorithms, e.g. zstd, are plugged by implementing this interface.
+type Compression interface {
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+	NewReader(r io.Reader) (io.ReadCloser, error)
+}
+
+// Gzip is the Compression with the default gzip level.
+var Gzip Compression = GzipLevel(gzip.DefaultCompression)
+
+// GzipLevel is the Compression with the given gzip level, see the gzip package constants.
+type GzipLevel int
+
+// NewWriter creates the gzip writer.
+func (l GzipLevel) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriterLevel(w, int(l))
+}
+
+// NewReader creates the gzip reader.
+func (l GzipLevel) NewReader(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
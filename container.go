@@ -0,0 +1,245 @@
+package gomap
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Scope defines how often the Container calls the constructor.
+type Scope int
+
+const (
+	// Singleton constructs the value once and returns it on every resolve.
+	Singleton Scope = iota
+	// Transient constructs the new value on every resolve.
+	Transient
+)
+
+type provider struct {
+	ctor  func(*Container) any
+	scope Scope
+}
+
+// resolveChain is the stack of the types being constructed by one top-level Resolve and its nested calls.
+type resolveChain struct {
+	path []reflect.Type
+	// waiting is the singleton constructed by the other chain this one waits for, guarded by the Container mutex.
+	waiting reflect.Type
+}
+
+// flight is the singleton being constructed, the concurrent resolvers of its type wait for it.
+type flight struct {
+	done  chan struct{}
+	owner *resolveChain
+	value any
+	err   error
+}
+
+// Container is a small dependency injection container keyed by the type of the provided values.
+//
+// The constructors resolve their own dependencies by calling Resolve on the Container they receive,
+// which tracks the chain of the types being constructed, so the dependency cycles are detected on every resolve.
+// The singletons are constructed once, the concurrent resolvers of the same type wait for the single construction.
+type Container struct {
+	providers Map[reflect.Type, *provider]
+	instances Map[reflect.Type, any]
+
+	mutex    *sync.Mutex
+	inflight map[reflect.Type]*flight
+	chain    *resolveChain
+}
+
+// NewContainer creates the empty Container.
+func NewContainer() *Container {
+	return &Container{
+		providers: From(map[reflect.Type]*provider{}),
+		instances: From(map[reflect.Type]any{}),
+		mutex:     &sync.Mutex{},
+		inflight:  make(map[reflect.Type]*flight),
+	}
+}
+
+// Provide registers the constructor of T in the Container, replacing the previous one and its constructed singleton.
+// The constructor receives the Container to resolve its dependencies from.
+func Provide[T any](c *Container, ctor func(*Container) T, scope Scope) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.providers.Add(typ, &provider{
+		ctor:  func(c *Container) any { return ctor(c) },
+		scope: scope,
+	})
+	c.instances.Delete(typ)
+}
+
+// Resolve return the value of T constructed by the provided constructor.
+// ErrNotProvided is returned if T has no constructor and ErrCycle if T depends on itself.
+func Resolve[T any](c *Container) (T, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	var t T
+
+	v, err := c.resolve(typ)
+	if err != nil {
+		return t, err
+	}
+
+	t, ok := v.(T)
+	if !ok && v != nil {
+		return t, &TypeMismatchError{
+			Expected: typ,
+			Actual:   reflect.TypeOf(v),
+		}
+	}
+
+	return t, nil
+}
+
+// MustResolve is like Resolve but panics on error. It's meant to be called from the constructors,
+// the panic is recovered by the outer Resolve and returned as its error.
+func MustResolve[T any](c *Container) T {
+	t, err := Resolve[T](c)
+	if err != nil {
+		panic(resolveError{err: err})
+	}
+
+	return t
+}
+
+type resolveError struct {
+	err error
+}
+
+func (c *Container) resolve(typ reflect.Type) (any, error) {
+	chain := c.chain
+	if chain == nil {
+		chain = &resolveChain{}
+	}
+
+	if err := chain.check(typ); err != nil {
+		return nil, err
+	}
+
+	c.mutex.Lock()
+
+	if v, exists := c.instances.Get(typ); exists {
+		c.mutex.Unlock()
+		return v, nil
+	}
+
+	p, exists := c.providers.Get(typ)
+	if !exists {
+		c.mutex.Unlock()
+		return nil, fmt.Errorf("resolve %v: %w", typ, ErrNotProvided)
+	}
+
+	if p.scope == Transient {
+		c.mutex.Unlock()
+		return c.construct(chain, typ, p)
+	}
+
+	if f, exists := c.inflight[typ]; exists {
+		if c.waitsFor(f.owner, chain) {
+			c.mutex.Unlock()
+			return nil, fmt.Errorf("resolve %v: waits for itself through the concurrent resolve: %w", typ, ErrCycle)
+		}
+
+		chain.waiting = typ
+		c.mutex.Unlock()
+
+		<-f.done
+
+		c.mutex.Lock()
+		chain.waiting = nil
+		c.mutex.Unlock()
+
+		return f.value, f.err
+	}
+
+	f := &flight{done: make(chan struct{}), owner: chain}
+	c.inflight[typ] = f
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.inflight, typ)
+		// the singleton of the constructor replaced during the construction is not cached.
+		if current, exists := c.providers.Get(typ); f.err == nil && exists && current == p {
+			c.instances.Add(typ, f.value)
+		}
+		c.mutex.Unlock()
+
+		close(f.done)
+	}()
+
+	// the panic of the constructor is reported to the waiters as the error and propagated to the caller.
+	f.err = fmt.Errorf("resolve %v: %w", typ, ErrPanicked)
+	f.value, f.err = c.construct(chain, typ, p)
+
+	return f.value, f.err
+}
+
+// waitsFor checks whether the owner chain waits, directly or through the other chains, for the chain. The mutex must be held.
+func (c *Container) waitsFor(owner, chain *resolveChain) bool {
+	for owner != nil {
+		if owner == chain {
+			return true
+		}
+
+		if owner.waiting == nil {
+			return false
+		}
+
+		f, exists := c.inflight[owner.waiting]
+		if !exists {
+			return false
+		}
+
+		owner = f.owner
+	}
+
+	return false
+}
+
+func (c *Container) construct(chain *resolveChain, typ reflect.Type, p *provider) (v any, err error) {
+	chain.path = append(chain.path, typ)
+	defer func() {
+		chain.path = chain.path[:len(chain.path)-1]
+	}()
+
+	defer func() {
+		if r := recover(); r != nil {
+			re, ok := r.(resolveError)
+			if !ok {
+				panic(r)
+			}
+
+			err = re.err
+		}
+	}()
+
+	scoped := *c
+	scoped.chain = chain
+
+	return p.ctor(&scoped), nil
+}
+
+// check return ErrCycle if the type is already being constructed by the chain.
+func (r *resolveChain) check(typ reflect.Type) error {
+	for i, resolving := range r.path {
+		if resolving == typ {
+			path := make([]string, 0, len(r.path)-i+1)
+			for _, t := range r.path[i:] {
+				path = append(path, t.String())
+			}
+
+			return fmt.Errorf("resolve %s -> %v: %w", strings.Join(path, " -> "), typ, ErrCycle)
+		}
+	}
+
+	return nil
+}
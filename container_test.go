@@ -0,0 +1,124 @@
+package gomap_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+type serviceA struct{ b *serviceB }
+
+type serviceB struct{ n int }
+
+func TestContainerResolve(t *testing.T) {
+	c := gomap.NewContainer()
+	gomap.Provide(c, func(*gomap.Container) *serviceB { return &serviceB{n: 1} }, gomap.Singleton)
+	gomap.Provide(c, func(c *gomap.Container) *serviceA {
+		return &serviceA{b: gomap.MustResolve[*serviceB](c)}
+	}, gomap.Transient)
+
+	a1, err := gomap.Resolve[*serviceA](c)
+	require.NoError(t, err)
+	a2, err := gomap.Resolve[*serviceA](c)
+	require.NoError(t, err)
+
+	assert.NotSame(t, a1, a2)
+	assert.Same(t, a1.b, a2.b)
+}
+
+func TestContainerNotProvided(t *testing.T) {
+	_, err := gomap.Resolve[*serviceA](gomap.NewContainer())
+	assert.ErrorIs(t, err, gomap.ErrNotProvided)
+}
+
+func TestContainerCycle(t *testing.T) {
+	c := gomap.NewContainer()
+	gomap.Provide(c, func(c *gomap.Container) *serviceA {
+		gomap.MustResolve[*serviceB](c)
+		return &serviceA{}
+	}, gomap.Singleton)
+	gomap.Provide(c, func(c *gomap.Container) *serviceB {
+		gomap.MustResolve[*serviceA](c)
+		return &serviceB{}
+	}, gomap.Singleton)
+
+	_, err := gomap.Resolve[*serviceA](c)
+	assert.ErrorIs(t, err, gomap.ErrCycle)
+}
+
+func TestContainerCycleAfterProvide(t *testing.T) {
+	c := gomap.NewContainer()
+	gomap.Provide(c, func(c *gomap.Container) *serviceA {
+		return &serviceA{b: gomap.MustResolve[*serviceB](c)}
+	}, gomap.Transient)
+	gomap.Provide(c, func(*gomap.Container) *serviceB { return &serviceB{} }, gomap.Transient)
+
+	_, err := gomap.Resolve[*serviceA](c)
+	require.NoError(t, err)
+
+	gomap.Provide(c, func(c *gomap.Container) *serviceB {
+		gomap.MustResolve[*serviceA](c)
+		return &serviceB{}
+	}, gomap.Transient)
+
+	_, err = gomap.Resolve[*serviceA](c)
+	assert.ErrorIs(t, err, gomap.ErrCycle)
+}
+
+func TestContainerConcurrentSingleton(t *testing.T) {
+	var calls atomic.Int32
+
+	c := gomap.NewContainer()
+	gomap.Provide(c, func(*gomap.Container) *serviceB {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return &serviceB{}
+	}, gomap.Singleton)
+
+	var wg sync.WaitGroup
+	resolved := make([]*serviceB, 8)
+
+	for i := range resolved {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			b, err := gomap.Resolve[*serviceB](c)
+			assert.NoError(t, err)
+			resolved[i] = b
+		}(i)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), calls.Load())
+	for _, b := range resolved {
+		assert.Same(t, resolved[0], b)
+	}
+}
+
+func TestContainerConstructorPanicReleasesWaiters(t *testing.T) {
+	c := gomap.NewContainer()
+	started := make(chan struct{})
+	var once sync.Once
+	gomap.Provide(c, func(*gomap.Container) *serviceB {
+		once.Do(func() { close(started) })
+		time.Sleep(50 * time.Millisecond)
+		panic("boom")
+	}, gomap.Singleton)
+
+	go func() {
+		defer func() { _ = recover() }()
+		_, _ = gomap.Resolve[*serviceB](c)
+	}()
+
+	<-started
+	_, err := gomap.Resolve[*serviceB](c)
+	assert.ErrorIs(t, err, gomap.ErrPanicked)
+}
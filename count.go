@@ -0,0 +1,35 @@
+package gomap
+
+// CountOccurrences counts how many times each value occurs in the slice.
+func CountOccurrences[T comparable](values []T) Map[T, int] {
+	counts := make(map[T]int)
+
+	for _, v := range values {
+		counts[v]++
+	}
+
+	return newMap(counts)
+}
+
+// MergeCounts joins the counts together to Map[T, int], summing the counts of the same value.
+func MergeCounts[T comparable](counts ...Map[T, int]) Map[T, int] {
+	return JoinSum(counts...)
+}
+
+// CountBy counts the elements of Map[K, V] by the key derived from each element under the read lock.
+func CountBy[K comparable, V any, G comparable](m Map[K, V], fn func(K, V) G) Map[G, int] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	counts := make(map[G]int)
+	for k, v := range m.innerMap {
+		counts[fn(k, v)]++
+	}
+
+	return newMap(counts)
+}
+
+// ValueCounts counts how many keys of Map[K, V] hold each value.
+func ValueCounts[K, V comparable](m Map[K, V]) Map[V, int] {
+	return CountBy(m, func(_ K, v V) V { return v })
+}
@@ -0,0 +1,174 @@
+package gomap
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	aeadRecordSize = 64 * 1024
+	aeadStreamID   = 16
+)
+
+// aeadWriter seals the stream into the header of aeadStreamID random bytes identifying the stream, followed by
+// the records of up to aeadRecordSize bytes: 4 bytes big endian length, the nonce and the sealed payload.
+// The associated data of each record is the stream id, its sequence number and the final flag, so the reordered,
+// dropped or truncated records, as well as the records spliced from the other stream sealed by the same key, are detected on read.
+type aeadWriter struct {
+	w    io.Writer
+	aead cipher.AEAD
+	buf  []byte
+	id   []byte
+	seq  uint64
+}
+
+func newAEADWriter(w io.Writer, aead cipher.AEAD) *aeadWriter {
+	return &aeadWriter{w: w, aead: aead, buf: make([]byte, 0, aeadRecordSize)}
+}
+
+func (a *aeadWriter) Write(p []byte) (int, error) {
+	written := 0
+
+	for len(p) > 0 {
+		n := copy(a.buf[len(a.buf):cap(a.buf)], p)
+		a.buf = a.buf[:len(a.buf)+n]
+		p = p[n:]
+		written += n
+
+		if len(a.buf) == cap(a.buf) {
+			if err := a.writeRecord(false); err != nil {
+				return written, err
+			}
+		}
+	}
+
+	return written, nil
+}
+
+// Close writes the final record, it does not close the underlying writer.
+func (a *aeadWriter) Close() error {
+	return a.writeRecord(true)
+}
+
+func (a *aeadWriter) writeRecord(final bool) error {
+	if a.id == nil {
+		id := make([]byte, aeadStreamID)
+		if _, err := rand.Read(id); err != nil {
+			return fmt.Errorf("generate stream id: %w", err)
+		}
+
+		if _, err := a.w.Write(id); err != nil {
+			return err
+		}
+
+		a.id = id
+	}
+
+	nonce := make([]byte, a.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+
+	sealed := a.aead.Seal(nonce, nonce, a.buf, recordAD(a.id, a.seq, final))
+
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(sealed)))
+
+	if _, err := a.w.Write(length[:]); err != nil {
+		return err
+	}
+
+	if _, err := a.w.Write(sealed); err != nil {
+		return err
+	}
+
+	a.buf = a.buf[:0]
+	a.seq++
+
+	return nil
+}
+
+type aeadReader struct {
+	r     io.Reader
+	aead  cipher.AEAD
+	plain bytes.Reader
+	id    []byte
+	seq   uint64
+	final bool
+}
+
+func newAEADReader(r io.Reader, aead cipher.AEAD) *aeadReader {
+	return &aeadReader{r: r, aead: aead}
+}
+
+func (a *aeadReader) Read(p []byte) (int, error) {
+	for a.plain.Len() == 0 {
+		if a.final {
+			return 0, io.EOF
+		}
+
+		if err := a.readRecord(); err != nil {
+			return 0, err
+		}
+	}
+
+	return a.plain.Read(p)
+}
+
+func (a *aeadReader) readRecord() error {
+	if a.id == nil {
+		id := make([]byte, aeadStreamID)
+		if _, err := io.ReadFull(a.r, id); err != nil {
+			return fmt.Errorf("read stream id: %w", ErrCorruptedStream)
+		}
+
+		a.id = id
+	}
+
+	var length [4]byte
+	if _, err := io.ReadFull(a.r, length[:]); err != nil {
+		return fmt.Errorf("read record %d: %w", a.seq, ErrCorruptedStream)
+	}
+
+	size := binary.BigEndian.Uint32(length[:])
+	if size > uint32(aeadRecordSize+a.aead.NonceSize()+a.aead.Overhead()) {
+		return fmt.Errorf("read record %d of %d bytes: %w", a.seq, size, ErrCorruptedStream)
+	}
+
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(a.r, sealed); err != nil || len(sealed) < a.aead.NonceSize() {
+		return fmt.Errorf("read record %d: %w", a.seq, ErrCorruptedStream)
+	}
+
+	nonce, ciphertext := sealed[:a.aead.NonceSize()], sealed[a.aead.NonceSize():]
+
+	// The record is either the last one or not, only one of the associated data can authenticate it.
+	for _, final := range []bool{false, true} {
+		plain, err := a.aead.Open(nil, nonce, ciphertext, recordAD(a.id, a.seq, final))
+		if err == nil {
+			a.plain.Reset(plain)
+			a.final = final
+			a.seq++
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("open record %d: %w", a.seq, ErrCorruptedStream)
+}
+
+func recordAD(id []byte, seq uint64, final bool) []byte {
+	ad := make([]byte, len(id)+9)
+	copy(ad, id)
+	binary.BigEndian.PutUint64(ad[len(id):], seq)
+
+	if final {
+		ad[len(id)+8] = 1
+	}
+
+	return ad
+}
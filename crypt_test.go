@@ -0,0 +1,134 @@
+package gomap_test
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func newGCM(t *testing.T) cipher.AEAD {
+	block, err := aes.NewCipher(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, err)
+
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	return aead
+}
+
+// exportSealed exports the Map large enough to span several records.
+func exportSealed(t *testing.T, aead cipher.AEAD) []byte {
+	m := gomap.New[string, string]()
+	for i := 0; i < 2000; i++ {
+		m.Add(strconv.Itoa(i), strings.Repeat("v", 100))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, m.Export(&buf, gomap.WithAEAD(aead)))
+
+	return buf.Bytes()
+}
+
+// splitRecords splits the sealed stream into its header and the length-prefixed records.
+func splitRecords(stream []byte) (header []byte, records [][]byte) {
+	header, stream = stream[:16], stream[16:]
+
+	for len(stream) > 0 {
+		n := 4 + int(binary.BigEndian.Uint32(stream))
+		records = append(records, stream[:n])
+		stream = stream[n:]
+	}
+
+	return header, records
+}
+
+func TestExportImportWithAEAD(t *testing.T) {
+	aead := newGCM(t)
+
+	sealed := exportSealed(t, aead)
+	assert.NotContains(t, string(sealed), strings.Repeat("v", 100))
+
+	m := gomap.New[string, string]()
+	require.NoError(t, m.Import(bytes.NewReader(sealed), gomap.WithAEAD(aead)))
+	assert.Equal(t, 2000, m.Len())
+}
+
+func TestImportWithAEADRejectsSplicedStream(t *testing.T) {
+	aead := newGCM(t)
+
+	headerA, recordsA := splitRecords(exportSealed(t, aead))
+	_, recordsB := splitRecords(exportSealed(t, aead))
+	require.Greater(t, len(recordsA), 2)
+
+	spliced := append(append([]byte{}, headerA...), recordsA[0]...)
+	for _, record := range recordsB[1:] {
+		spliced = append(spliced, record...)
+	}
+
+	err := gomap.New[string, string]().Import(bytes.NewReader(spliced), gomap.WithAEAD(aead))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+}
+
+func TestImportWithAEADRejectsTamperedStream(t *testing.T) {
+	aead := newGCM(t)
+
+	sealed := exportSealed(t, aead)
+	sealed[len(sealed)/2] ^= 1
+
+	err := gomap.New[string, string]().Import(bytes.NewReader(sealed), gomap.WithAEAD(aead))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+}
+
+func TestImportWithAEADRejectsTruncatedStream(t *testing.T) {
+	aead := newGCM(t)
+
+	header, records := splitRecords(exportSealed(t, aead))
+
+	// dropping the final record must not look like the end of the stream.
+	truncated := append([]byte{}, header...)
+	for _, record := range records[:len(records)-1] {
+		truncated = append(truncated, record...)
+	}
+
+	err := gomap.New[string, string]().Import(bytes.NewReader(truncated), gomap.WithAEAD(aead))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+
+	err = gomap.New[string, string]().Import(bytes.NewReader(header[:8]), gomap.WithAEAD(aead))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+}
+
+func TestImportWithAEADRejectsReorderedRecords(t *testing.T) {
+	aead := newGCM(t)
+
+	header, records := splitRecords(exportSealed(t, aead))
+	records[0], records[1] = records[1], records[0]
+
+	reordered := append([]byte{}, header...)
+	for _, record := range records {
+		reordered = append(reordered, record...)
+	}
+
+	err := gomap.New[string, string]().Import(bytes.NewReader(reordered), gomap.WithAEAD(aead))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+}
+
+func TestImportWithAEADRejectsOtherKey(t *testing.T) {
+	sealed := exportSealed(t, newGCM(t))
+
+	block, err := aes.NewCipher(bytes.Repeat([]byte{2}, 32))
+	require.NoError(t, err)
+	other, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	err = gomap.New[string, string]().Import(bytes.NewReader(sealed), gomap.WithAEAD(other))
+	assert.ErrorIs(t, err, gomap.ErrCorruptedStream)
+}
@@ -0,0 +1,179 @@
+package gomap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+type dumpOptions struct {
+	indent      string
+	maxValueLen int
+}
+
+// DumpOption configures the output of Dump.
+type DumpOption func(*dumpOptions)
+
+// WithIndent sets the indentation used for each element, two spaces by default.
+func WithIndent(indent string) DumpOption {
+	return func(o *dumpOptions) {
+		o.indent = indent
+	}
+}
+
+// WithMaxValueLen truncates the rendered values longer than n runes. Zero means no limit.
+func WithMaxValueLen(n int) DumpOption {
+	return func(o *dumpOptions) {
+		o.maxValueLen = n
+	}
+}
+
+// Dump writes the human-readable multi-line rendering of Map[K, V] sorted by key to w, see WithCollation.
+func (m Map[K, V]) Dump(w io.Writer, opts ...DumpOption) error {
+	o := dumpOptions{indent: "  "}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	type line struct {
+		raw        K
+		key, value string
+	}
+
+	m.mutex.RLock()
+	lines := make([]line, 0, len(m.innerMap))
+	for k, v := range m.innerMap {
+		lines = append(lines, line{raw: k, key: formatDumpValue(k, 0), value: formatDumpValue(v, o.maxValueLen)})
+	}
+	m.mutex.RUnlock()
+
+	sort.Slice(lines, func(i, j int) bool {
+		return m.lessKey(lines[i].raw, lines[j].raw)
+	})
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "Map[%s, %s] (%d) {\n", typeName[K](), typeName[V](), len(lines))
+	for _, l := range lines {
+		fmt.Fprintf(bw, "%s%s: %s,\n", o.indent, l.key, l.value)
+	}
+	bw.WriteString("}\n")
+
+	return bw.Flush()
+}
+
+// DumpString return the rendering of Map[K, V] produced by Dump as string.
+func (m Map[K, V]) DumpString(opts ...DumpOption) string {
+	var sb strings.Builder
+	_ = m.Dump(&sb, opts...)
+
+	return sb.String()
+}
+
+// WriteTable writes the elements of Map[K, V] sorted by key to w as an aligned plain text table.
+// The columns function renders each element as a row, the optional header is written first.
+func (m Map[K, V]) WriteTable(w io.Writer, columns func(K, V) []string, header ...string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	if len(header) > 0 {
+		fmt.Fprintln(tw, strings.Join(header, "\t"))
+	}
+
+	for _, row := range m.rows(columns) {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+
+	return tw.Flush()
+}
+
+// WriteMarkdown writes the elements of Map[K, V] sorted by key to w as a markdown table.
+// The columns function renders each element as a row, the header is required by the markdown syntax.
+func (m Map[K, V]) WriteMarkdown(w io.Writer, columns func(K, V) []string, header ...string) error {
+	bw := bufio.NewWriter(w)
+
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+
+	writeMarkdownRow(bw, header)
+	writeMarkdownRow(bw, separator)
+
+	for _, row := range m.rows(columns) {
+		writeMarkdownRow(bw, row)
+	}
+
+	return bw.Flush()
+}
+
+func (m Map[K, V]) rows(columns func(K, V) []string) [][]string {
+	entries := m.ToSlice(func(a, b Entry[K, V]) bool {
+		return m.lessKey(a.Key, b.Key)
+	})
+
+	rows := make([][]string, 0, len(entries))
+	for _, e := range entries {
+		rows = append(rows, columns(e.Key, e.Value))
+	}
+
+	return rows
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", " ")
+
+func writeMarkdownRow(w *bufio.Writer, cells []string) {
+	w.WriteString("|")
+	for _, cell := range cells {
+		w.WriteString(" ")
+		w.WriteString(markdownEscaper.Replace(cell))
+		w.WriteString(" |")
+	}
+	w.WriteString("\n")
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func formatDumpValue(v any, maxLen int) string {
+	var s string
+	if str, ok := v.(string); ok {
+		s = fmt.Sprintf("%q", str)
+	} else {
+		s = fmt.Sprintf("%+v", v)
+	}
+
+	if maxLen > 0 {
+		if runes := []rune(s); len(runes) > maxLen {
+			s = string(runes[:maxLen]) + "..."
+		}
+	}
+
+	return s
+}
+
+// lessAny orders the values by the name of their dynamic type first, so the order stays transitive for mixed types,
+// then the values of the same type: numbers and strings naturally, everything else by its formatted form.
+func lessAny(a, b any) bool {
+	ra, rb := reflect.ValueOf(a), reflect.ValueOf(b)
+
+	if ta, tb := fmt.Sprintf("%T", a), fmt.Sprintf("%T", b); ta != tb {
+		return ta < tb
+	}
+
+	switch ra.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return ra.Int() < rb.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return ra.Uint() < rb.Uint()
+	case reflect.Float32, reflect.Float64:
+		return ra.Float() < rb.Float()
+	case reflect.String:
+		return ra.String() < rb.String()
+	}
+
+	return fmt.Sprintf("%+v", a) < fmt.Sprintf("%+v", b)
+}
@@ -0,0 +1,23 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestDumpMixedKeyTypes(t *testing.T) {
+	m := gomap.From(map[any]int{10: 1, "9": 2, 2: 3, "10": 4, int64(5): 5, 1.5: 6})
+
+	assert.Equal(t, `Map[interface {}, int] (6) {
+  1.5: 6,
+  2: 3,
+  10: 1,
+  5: 5,
+  "10": 4,
+  "9": 2,
+}
+`, m.DumpString())
+}
@@ -0,0 +1,7 @@
+package gomap
+
+// Entry is a key-value pair of Map[K, V].
+type Entry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
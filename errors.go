@@ -0,0 +1,31 @@
+package gomap
+
+import "errors"
+
+var (
+	// ErrKeyNotFound is returned when the key does not exist in the map.
+	ErrKeyNotFound = errors.New("gomap: key not found")
+	// ErrKeyExists is returned when the key already exists in the map.
+	ErrKeyExists = errors.New("gomap: key already exists")
+	// ErrTypeMismatch is returned when the stored value has the type other than requested.
+	ErrTypeMismatch = errors.New("gomap: type mismatch")
+	// ErrNotRegistered is returned when the Registry has no Map with the given name.
+	ErrNotRegistered = errors.New("gomap: map is not registered")
+	// ErrCycle is returned when the dependencies form a cycle.
+	ErrCycle = errors.New("gomap: dependency cycle")
+	// ErrNotProvided is returned when the Container has no constructor for the requested type.
+	ErrNotProvided = errors.New("gomap: type is not provided")
+	// ErrFieldRequired is reported by the Schema for the missing required field.
+	ErrFieldRequired = errors.New("gomap: required field is missing")
+	// ErrFieldKind is reported by the Schema for the value which cannot be coerced to the field kind.
+	ErrFieldKind = errors.New("gomap: value cannot be coerced")
+	// ErrQuotaExceeded is returned when the new key does not fit the quota of its namespace.
+	ErrQuotaExceeded = errors.New("gomap: quota exceeded")
+	// ErrCorruptedStream is returned when the encrypted stream cannot be authenticated or is truncated.
+	ErrCorruptedStream = errors.New("gomap: corrupted encrypted stream")
+	// ErrPanicked is returned to the callers waiting for the result of the function, which panicked,
+	// e.g. the memoized function or the Container constructor.
+	ErrPanicked = errors.New("gomap: function panicked")
+	// ErrHashCollision is returned when the distinct keys cannot be told apart by their hash.
+	ErrHashCollision = errors.New("gomap: keys collide under every hash seed")
+)
@@ -0,0 +1,70 @@
+package gomap
+
+import (
+	"context"
+	"runtime"
+	"time"
+)
+
+// evictBatchSize is the number of keys StartEvictor checks under one write lock.
+const evictBatchSize = 256
+
+// StartEvictor starts the goroutine deleting the elements matching the pred every interval until ctx is done.
+// The keys are copied under one read lock, which blocks the writers for the time of copying all the keys,
+// then the pred is checked and the elements are deleted in the batches of the small size, each under its own write lock,
+// so the writers are not blocked for the time of calling the pred on the whole Map.
+// The pred is called under the write lock and must not access the Map. The interval must be positive.
+// The returned channel is closed when the goroutine exits after ctx is done, so the shutdown can wait for it.
+func (m Map[K, V]) StartEvictor(ctx context.Context, interval time.Duration, pred func(K, V) bool) <-chan struct{} {
+	done := make(chan struct{})
+
+	// the ticker is created by the caller, so the non-positive interval panics there, not in the goroutine.
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.deleteInBatches(ctx, m.AppendKeys(nil), evictBatchSize, pred)
+			}
+		}
+	}()
+
+	return done
+}
+
+// deleteInBatches deletes the keys whose current elements match the pred, taking the write lock per batch,
+// and return the number of deleted elements. It stops early when ctx is done.
+func (m Map[K, V]) deleteInBatches(ctx context.Context, keys []K, size int, pred func(K, V) bool) int {
+	var deleted int
+
+	for len(keys) > 0 {
+		if ctx.Err() != nil {
+			return deleted
+		}
+
+		n := size
+		if n > len(keys) {
+			n = len(keys)
+		}
+
+		m.mutex.Lock()
+		for _, k := range keys[:n] {
+			if v, exists := m.innerMap[k]; exists && pred(k, v) {
+				m.remove(k)
+				deleted++
+			}
+		}
+		m.mutex.Unlock()
+
+		keys = keys[n:]
+		runtime.Gosched()
+	}
+
+	return deleted
+}
@@ -0,0 +1,47 @@
+package gomap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestStartEvictor(t *testing.T) {
+	m := gomap.New[int, int]()
+	for i := 0; i < 1000; i++ {
+		m.Add(i, i)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	m.StartEvictor(ctx, time.Millisecond, func(_, v int) bool { return v%2 == 0 })
+
+	assert.Eventually(t, func() bool {
+		return !m.Any(func(_, v int) bool { return v%2 == 0 })
+	}, time.Second, time.Millisecond)
+	assert.True(t, m.Exists(1))
+}
+
+func TestStartEvictorNonPositiveInterval(t *testing.T) {
+	assert.Panics(t, func() {
+		gomap.New[int, int]().StartEvictor(context.Background(), 0, func(int, int) bool { return true })
+	})
+}
+
+func TestStartEvictorDoneAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := gomap.New[int, int]().StartEvictor(ctx, time.Millisecond, func(int, int) bool { return true })
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the evictor did not exit after ctx is done")
+	}
+}
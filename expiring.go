@@ -0,0 +1,231 @@
+package gomap
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type expiringEntry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// ExpiringOption configures the ExpiringMap.
+type ExpiringOption[K comparable, V any] func(*ExpiringMap[K, V])
+
+// WithExpiringClock sets the Clock of the ExpiringMap, SystemClock by default.
+func WithExpiringClock[K comparable, V any](clock Clock) ExpiringOption[K, V] {
+	return func(e *ExpiringMap[K, V]) {
+		e.clock = clock
+	}
+}
+
+// WithOnEvict sets the callback called for each element deleted on expiration, outside of the lock.
+func WithOnEvict[K comparable, V any](onEvict func(K, V)) ExpiringOption[K, V] {
+	return func(e *ExpiringMap[K, V]) {
+		e.onEvict = onEvict
+	}
+}
+
+// ExpiringMap is a concurrency safe map with the per key TTL. The expired elements are invisible immediately
+// and deleted by the background janitor, which runs until Close or Shutdown.
+type ExpiringMap[K comparable, V any] struct {
+	entries Map[K, expiringEntry[V]]
+	clock   Clock
+	onEvict func(K, V)
+	stop    chan struct{}
+	done    chan struct{}
+	once    *sync.Once
+}
+
+// NewExpiringMap creates the empty ExpiringMap[K, V] and starts its janitor deleting the expired elements every interval.
+// The non-positive interval starts no janitor, the expired elements are then deleted only by DeleteExpired.
+func NewExpiringMap[K comparable, V any](interval time.Duration, opts ...ExpiringOption[K, V]) ExpiringMap[K, V] {
+	e := ExpiringMap[K, V]{
+		entries: From(map[K]expiringEntry[V]{}),
+		clock:   SystemClock,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		once:    &sync.Once{},
+	}
+
+	for _, opt := range opts {
+		opt(&e)
+	}
+
+	if interval > 0 {
+		go e.janitor(interval)
+	} else {
+		close(e.done)
+	}
+
+	return e
+}
+
+// Add adds the element expiring after ttl, zero ttl means the element never expires.
+func (e ExpiringMap[K, V]) Add(k K, v V, ttl time.Duration) ExpiringMap[K, V] {
+	entry := expiringEntry[V]{value: v}
+	if ttl > 0 {
+		entry.expiresAt = e.clock.Now().Add(ttl)
+	}
+
+	e.entries.Add(k, entry)
+
+	return e
+}
+
+// Get return the value by key, if it exists and is not expired.
+func (e ExpiringMap[K, V]) Get(k K) (V, bool) {
+	if entry, exists := e.entries.Get(k); exists && !e.expired(entry, e.clock.Now()) {
+		return entry.value, true
+	}
+
+	var v V
+	return v, false
+}
+
+// TTL return the time left until the element expires, zero is returned for the element which never expires.
+func (e ExpiringMap[K, V]) TTL(k K) (time.Duration, bool) {
+	now := e.clock.Now()
+
+	entry, exists := e.entries.Get(k)
+	if !exists || e.expired(entry, now) {
+		return 0, false
+	}
+
+	if entry.expiresAt.IsZero() {
+		return 0, true
+	}
+
+	return entry.expiresAt.Sub(now), true
+}
+
+// ExpiringWithin return the copy of the elements expiring within d from now, e.g. to refresh the critical ones
+// before they fall out. The expired elements and the elements which never expire are not included.
+func (e ExpiringMap[K, V]) ExpiringWithin(d time.Duration) Map[K, V] {
+	m := e.entries
+	now := e.clock.Now()
+	deadline := now.Add(d)
+
+	within := make(map[K]V)
+
+	m.mutex.RLock()
+	for k, entry := range m.innerMap {
+		if !entry.expiresAt.IsZero() && !e.expired(entry, now) && !entry.expiresAt.After(deadline) {
+			within[k] = entry.value
+		}
+	}
+	m.mutex.RUnlock()
+
+	return From(within)
+}
+
+// ExpiryHistogram return the number of elements by the time left until they expire, bucketed by the ascending bounds:
+// the i-th count is the number of elements expiring within bounds[i], but not within bounds[i-1].
+// The last count, at len(bounds), is the number of the remaining elements including the ones which never expire.
+// The expired elements are not counted.
+func (e ExpiringMap[K, V]) ExpiryHistogram(bounds ...time.Duration) []int {
+	m := e.entries
+	now := e.clock.Now()
+
+	counts := make([]int, len(bounds)+1)
+
+	m.mutex.RLock()
+	for _, entry := range m.innerMap {
+		if e.expired(entry, now) {
+			continue
+		}
+
+		bucket := len(bounds)
+		if !entry.expiresAt.IsZero() {
+			left := entry.expiresAt.Sub(now)
+			bucket = sort.Search(len(bounds), func(i int) bool { return left <= bounds[i] })
+		}
+
+		counts[bucket]++
+	}
+	m.mutex.RUnlock()
+
+	return counts
+}
+
+// Delete deletes the element by key, the OnEvict callback is not called.
+func (e ExpiringMap[K, V]) Delete(k K) bool {
+	return e.entries.Delete(k)
+}
+
+// Len return the number of elements including the expired ones not yet deleted by the janitor.
+func (e ExpiringMap[K, V]) Len() int {
+	return e.entries.Len()
+}
+
+// DeleteExpired deletes the expired elements and return their number, the janitor calls it every interval.
+func (e ExpiringMap[K, V]) DeleteExpired() int {
+	m := e.entries
+	now := e.clock.Now()
+
+	var evicted []Entry[K, V]
+
+	m.mutex.Lock()
+	for k, entry := range m.innerMap {
+		if e.expired(entry, now) {
+			m.remove(k)
+			evicted = append(evicted, Entry[K, V]{Key: k, Value: entry.value})
+		}
+	}
+	m.mutex.Unlock()
+
+	if e.onEvict != nil {
+		for _, entry := range evicted {
+			e.onEvict(entry.Key, entry.Value)
+		}
+	}
+
+	return len(evicted)
+}
+
+// Close stops the janitor without waiting for it, see Shutdown.
+// The ExpiringMap remains usable, but the expired elements are not deleted anymore.
+func (e ExpiringMap[K, V]) Close() {
+	e.once.Do(func() {
+		close(e.stop)
+	})
+}
+
+// Shutdown stops the janitor, waits until it exits and deletes the expired elements the last time,
+// so the OnEvict callback sees every element expired before the shutdown. ctx bounds the waiting for the janitor,
+// if it's done first, its error is returned and the last deletion is skipped.
+func (e ExpiringMap[K, V]) Shutdown(ctx context.Context) error {
+	e.Close()
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	e.DeleteExpired()
+
+	return nil
+}
+
+func (e ExpiringMap[K, V]) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer close(e.done)
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			e.DeleteExpired()
+		}
+	}
+}
+
+func (e ExpiringMap[K, V]) expired(entry expiringEntry[V], now time.Time) bool {
+	return !entry.expiresAt.IsZero() && !now.Before(entry.expiresAt)
+}
@@ -0,0 +1,113 @@
+package gomap_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestExpiringMap(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+
+	var evicted []string
+	e := gomap.NewExpiringMap(0,
+		gomap.WithExpiringClock[string, int](clock),
+		gomap.WithOnEvict(func(k string, _ int) { evicted = append(evicted, k) }),
+	)
+	defer e.Close()
+
+	e.Add("short", 1, time.Second).Add("long", 2, time.Minute).Add("forever", 3, 0)
+
+	clock.Advance(2 * time.Second)
+
+	_, exists := e.Get("short")
+	assert.False(t, exists)
+
+	v, exists := e.Get("long")
+	assert.True(t, exists)
+	assert.Equal(t, 2, v)
+
+	assert.Equal(t, 1, e.DeleteExpired())
+	assert.Equal(t, []string{"short"}, evicted)
+	assert.Equal(t, 2, e.Len())
+}
+
+func TestExpiringMapJanitor(t *testing.T) {
+	e := gomap.NewExpiringMap[string, int](time.Millisecond)
+	defer e.Close()
+
+	e.Add("k", 1, time.Millisecond)
+
+	assert.Eventually(t, func() bool { return e.Len() == 0 }, time.Second, time.Millisecond)
+}
+
+func TestExpiringMapExpiringWithin(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	e := gomap.NewExpiringMap(0, gomap.WithExpiringClock[string, int](clock))
+	defer e.Close()
+
+	e.Add("expired", 0, time.Second).
+		Add("soon", 1, 10*time.Second).
+		Add("edge", 2, 30*time.Second).
+		Add("later", 3, time.Hour).
+		Add("forever", 4, 0)
+
+	clock.Advance(time.Second)
+
+	within := e.ExpiringWithin(29 * time.Second)
+	assert.Equal(t, map[string]int{"soon": 1, "edge": 2}, within.MapCopy())
+
+	within.Add("other", 5)
+	_, exists := e.Get("other")
+	assert.False(t, exists)
+}
+
+func TestExpiringMapExpiryHistogram(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	e := gomap.NewExpiringMap(0, gomap.WithExpiringClock[string, int](clock))
+	defer e.Close()
+
+	e.Add("expired", 0, time.Second).
+		Add("a", 1, 5*time.Second).
+		Add("b", 2, 10*time.Second).
+		Add("c", 3, 11*time.Second).
+		Add("d", 4, time.Hour).
+		Add("forever", 5, 0)
+
+	clock.Advance(time.Second)
+
+	assert.Equal(t, []int{2, 1, 2}, e.ExpiryHistogram(9*time.Second, time.Minute))
+	assert.Equal(t, []int{5}, e.ExpiryHistogram())
+}
+
+func TestExpiringMapShutdown(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+
+	var evicted []string
+	e := gomap.NewExpiringMap(time.Hour,
+		gomap.WithExpiringClock[string, int](clock),
+		gomap.WithOnEvict(func(k string, _ int) { evicted = append(evicted, k) }),
+	)
+
+	e.Add("expired", 1, time.Second).Add("live", 2, time.Minute)
+	clock.Advance(2 * time.Second)
+
+	require.NoError(t, e.Shutdown(context.Background()))
+	assert.Equal(t, []string{"expired"}, evicted)
+	assert.Equal(t, 1, e.Len())
+
+	require.NoError(t, e.Shutdown(context.Background()), "Shutdown is idempotent")
+}
+
+func TestExpiringMapShutdownWithoutJanitor(t *testing.T) {
+	e := gomap.NewExpiringMap[string, int](0)
+	e.Close()
+
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
@@ -0,0 +1,137 @@
+package gomap
+
+import (
+	"fmt"
+	"hash/fnv"
+	"reflect"
+)
+
+// FlagRule decides whether the flag is enabled for the given attributes.
+type FlagRule interface {
+	Evaluate(flag string, attrs Map[string, any]) bool
+}
+
+// FlagRuleFunc adapts the function to FlagRule.
+type FlagRuleFunc func(flag string, attrs Map[string, any]) bool
+
+// Evaluate calls f(flag, attrs).
+func (f FlagRuleFunc) Evaluate(flag string, attrs Map[string, any]) bool {
+	return f(flag, attrs)
+}
+
+// Constant enables or disables the flag for everyone.
+type Constant bool
+
+// Evaluate return the constant value.
+func (c Constant) Evaluate(string, Map[string, any]) bool {
+	return bool(c)
+}
+
+// Rollout enables the flag for the stable percentage of the attribute values, e.g. user ids.
+// The same attribute value always gets the same result for the same flag.
+type Rollout struct {
+	Attribute string
+	// Percent in range [0, 100].
+	Percent float64
+}
+
+// Evaluate hashes the flag name together with the attribute value into a bucket and compares it with Percent.
+func (r Rollout) Evaluate(flag string, attrs Map[string, any]) bool {
+	v, exists := attrs.Get(r.Attribute)
+	if !exists {
+		return false
+	}
+
+	h := fnv.New32a()
+	fmt.Fprintf(h, "%s:%v", flag, v)
+
+	return float64(h.Sum32()%10000) < r.Percent*100
+}
+
+// AttributeMatch enables the flag when the attribute equals to one of the values.
+type AttributeMatch struct {
+	Attribute string
+	Values    []any
+}
+
+// Evaluate checks the attribute against the values.
+func (m AttributeMatch) Evaluate(_ string, attrs Map[string, any]) bool {
+	v, exists := attrs.Get(m.Attribute)
+	if !exists {
+		return false
+	}
+
+	for _, expected := range m.Values {
+		if reflect.DeepEqual(v, expected) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllOf enables the flag when each rule enables it.
+type AllOf []FlagRule
+
+// Evaluate evaluates the rules until the first one disabling the flag.
+func (rules AllOf) Evaluate(flag string, attrs Map[string, any]) bool {
+	for _, rule := range rules {
+		if !rule.Evaluate(flag, attrs) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AnyOf enables the flag when any of the rules enables it.
+type AnyOf []FlagRule
+
+// Evaluate evaluates the rules until the first one enabling the flag.
+func (rules AnyOf) Evaluate(flag string, attrs Map[string, any]) bool {
+	for _, rule := range rules {
+		if rule.Evaluate(flag, attrs) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FlagMap is a concurrency safe set of feature flags, each defined by the FlagRule.
+// The rules can be replaced at runtime and the following evaluations see the new rule immediately.
+type FlagMap struct {
+	rules Map[string, FlagRule]
+}
+
+// NewFlagMap creates the FlagMap from the rules by flag name.
+func NewFlagMap(rules map[string]FlagRule) FlagMap {
+	copied := make(map[string]FlagRule, len(rules))
+	for flag, rule := range rules {
+		copied[flag] = rule
+	}
+
+	return FlagMap{rules: From(copied)}
+}
+
+// Set sets the rule of the flag.
+func (f FlagMap) Set(flag string, rule FlagRule) FlagMap {
+	f.rules.Add(flag, rule)
+
+	return f
+}
+
+// Delete deletes the flag.
+func (f FlagMap) Delete(flag string) bool {
+	return f.rules.Delete(flag)
+}
+
+// Evaluate reports whether the flag is enabled for the attributes. The unknown flags are disabled.
+func (f FlagMap) Evaluate(flag string, attrs Map[string, any]) bool {
+	rule, exists := f.rules.Get(flag)
+	if !exists || rule == nil {
+		return false
+	}
+
+	return rule.Evaluate(flag, attrs)
+}
@@ -0,0 +1,40 @@
+package gomap
+
+import (
+	"context"
+	"time"
+)
+
+// StartFlusher starts the goroutine detaching the accumulated elements every interval and handing them over to fn,
+// which owns the passed map, e.g. to write the aggregated metrics out. The empty batches are skipped.
+// When ctx is done, the remaining elements are flushed the last time and the returned channel is closed.
+// The interval must be positive.
+func (m Map[K, V]) StartFlusher(ctx context.Context, interval time.Duration, fn func(map[K]V)) <-chan struct{} {
+	done := make(chan struct{})
+
+	// the ticker is created by the caller, so the non-positive interval panics there, not in the goroutine.
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				m.flush(fn)
+				return
+			case <-ticker.C:
+				m.flush(fn)
+			}
+		}
+	}()
+
+	return done
+}
+
+func (m Map[K, V]) flush(fn func(map[K]V)) {
+	if batch := m.Detach(); len(batch) > 0 {
+		fn(batch)
+	}
+}
@@ -0,0 +1,60 @@
+package gomap_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestStartFlusher(t *testing.T) {
+	m := gomap.New[string, int]()
+
+	var (
+		mutex   sync.Mutex
+		flushed = make(map[string]int)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := m.StartFlusher(ctx, time.Hour, func(batch map[string]int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		for k, v := range batch {
+			flushed[k] += v
+		}
+	})
+
+	m.Add("a", 1).Add("b", 2)
+	cancel()
+	<-done
+
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, flushed)
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestStartFlusherNonPositiveInterval(t *testing.T) {
+	assert.Panics(t, func() {
+		gomap.New[int, int]().StartFlusher(context.Background(), 0, func(map[int]int) {})
+	})
+}
+
+func TestStartFlusherConcurrentReads(t *testing.T) {
+	m := gomap.New[int, int]()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := m.StartFlusher(ctx, time.Millisecond, func(map[int]int) {})
+
+	for i := 0; i < 1000; i++ {
+		m.Add(i, i)
+		_ = m.Len()
+		_ = m.Filter(func(int, int) bool { return true })
+	}
+
+	cancel()
+	<-done
+}
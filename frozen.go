@@ -0,0 +1,177 @@
+package gomap
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// maxDisplacement bounds the search of the bucket displacement, the Frozen is rebuilt with the new hash seed past it.
+	maxDisplacement = 1 << 16
+	// maxFrozenSeeds bounds the number of the hash seeds tried by BuildFrozen.
+	maxFrozenSeeds = 8
+)
+
+// Frozen is an immutable map built once by BuildFrozen for the static lookup tables, e.g. loaded at init.
+// It uses the minimal perfect hash (hash and displace), so Get probes exactly one slot, takes no lock and does not allocate
+// for the string and integer keys.
+type Frozen[K comparable, V any] struct {
+	hash   func(K) uint64
+	disp   []uint32
+	keys   []K
+	values []V
+}
+
+// BuildFrozen builds the Frozen[K, V] from the builtin map, which is copied.
+// ErrHashCollision is returned if the distinct keys hash equally under every tried seed, e.g. the keys of the type
+// other than the strings and the integers, which format equally by fmt's %v.
+func BuildFrozen[K comparable, V any](m map[K]V) (Frozen[K, V], error) {
+	for i := 0; i < maxFrozenSeeds; i++ {
+		if f, ok := buildFrozen(m, hasher[K]()); ok {
+			return f, nil
+		}
+	}
+
+	return Frozen[K, V]{}, fmt.Errorf("build frozen map of %d keys: %w", len(m), ErrHashCollision)
+}
+
+// MustBuildFrozen is like BuildFrozen but panics on error, which suits the static tables built at init.
+func MustBuildFrozen[K comparable, V any](m map[K]V) Frozen[K, V] {
+	f, err := BuildFrozen(m)
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
+
+func buildFrozen[K comparable, V any](m map[K]V, hash func(K) uint64) (Frozen[K, V], bool) {
+	n := len(m)
+	f := Frozen[K, V]{
+		hash:   hash,
+		disp:   make([]uint32, n/2+1),
+		keys:   make([]K, n),
+		values: make([]V, n),
+	}
+
+	if n == 0 {
+		return f, true
+	}
+
+	buckets := make([][]K, len(f.disp))
+	hashes := make(map[K]uint64, n)
+	for k := range m {
+		h := hash(k)
+		hashes[k] = h
+		b := h % uint64(len(buckets))
+		buckets[b] = append(buckets[b], k)
+	}
+
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+
+	// the largest buckets are placed first, while most of the slots are free.
+	sort.Slice(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+
+	taken := make([]bool, n)
+	slots := make([]int, 0, n)
+
+	for _, b := range order {
+		bucket := buckets[b]
+		if len(bucket) == 0 {
+			break
+		}
+
+		d := uint32(0)
+		for ; ; d++ {
+			if d == maxDisplacement {
+				return f, false
+			}
+
+			slots = slots[:0]
+			for _, k := range bucket {
+				slot := f.slot(hashes[k], d)
+				if taken[slot] || containsSlot(slots, slot) {
+					break
+				}
+
+				slots = append(slots, slot)
+			}
+
+			if len(slots) == len(bucket) {
+				break
+			}
+		}
+
+		f.disp[b] = d
+		for i, k := range bucket {
+			taken[slots[i]] = true
+			f.keys[slots[i]] = k
+			f.values[slots[i]] = m[k]
+		}
+	}
+
+	return f, true
+}
+
+// Get return the value by key.
+func (f Frozen[K, V]) Get(k K) (V, bool) {
+	if len(f.keys) > 0 {
+		h := f.hash(k)
+		if slot := f.slot(h, f.disp[h%uint64(len(f.disp))]); f.keys[slot] == k {
+			return f.values[slot], true
+		}
+	}
+
+	var v V
+	return v, false
+}
+
+// Exists check if value by key exists in Frozen[K, V].
+func (f Frozen[K, V]) Exists(k K) bool {
+	_, exists := f.Get(k)
+
+	return exists
+}
+
+// Len return the number of elements.
+func (f Frozen[K, V]) Len() int {
+	return len(f.keys)
+}
+
+// Range calls fn for each element until fn returns false.
+func (f Frozen[K, V]) Range(fn func(K, V) bool) {
+	for i, k := range f.keys {
+		if !fn(k, f.values[i]) {
+			return
+		}
+	}
+}
+
+// Map return the copy of Frozen[K, V] as the mutable Map[K, V].
+func (f Frozen[K, V]) Map() Map[K, V] {
+	copied := make(map[K]V, len(f.keys))
+	for i, k := range f.keys {
+		copied[k] = f.values[i]
+	}
+
+	return newMap(copied)
+}
+
+func (f Frozen[K, V]) slot(h uint64, d uint32) int {
+	return int(mix(h+uint64(d)*0x9e3779b97f4a7c15) % uint64(len(f.keys)))
+}
+
+func containsSlot(slots []int, slot int) bool {
+	for _, s := range slots {
+		if s == slot {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,112 @@
+package gomap_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+type stringKey string
+
+type constStringer int
+
+func (constStringer) String() string { return "same" }
+
+func TestBuildFrozen(t *testing.T) {
+	m := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		m[fmt.Sprint("key", i)] = i
+	}
+
+	f, err := gomap.BuildFrozen(m)
+	require.NoError(t, err)
+	assert.Equal(t, len(m), f.Len())
+
+	for k, v := range m {
+		actual, exists := f.Get(k)
+		assert.True(t, exists, k)
+		assert.Equal(t, v, actual)
+	}
+
+	_, exists := f.Get("missing")
+	assert.False(t, exists)
+}
+
+func TestBuildFrozenEmpty(t *testing.T) {
+	f, err := gomap.BuildFrozen(map[int]string{})
+	require.NoError(t, err)
+
+	_, exists := f.Get(1)
+	assert.False(t, exists)
+}
+
+func TestBuildFrozenTypesFormattingEqually(t *testing.T) {
+	f, err := gomap.BuildFrozen(map[any]int{stringKey("x"): 1, "x": 2, int8(1): 3, int16(1): 4})
+	require.NoError(t, err)
+
+	v, _ := f.Get(stringKey("x"))
+	assert.Equal(t, 1, v)
+	v, _ = f.Get("x")
+	assert.Equal(t, 2, v)
+	v, _ = f.Get(int16(1))
+	assert.Equal(t, 4, v)
+}
+
+func TestBuildFrozenCollision(t *testing.T) {
+	_, err := gomap.BuildFrozen(map[constStringer]int{1: 1, 2: 2})
+	assert.ErrorIs(t, err, gomap.ErrHashCollision)
+}
+
+func TestFrozenGetDoesNotAllocate(t *testing.T) {
+	f := gomap.MustBuildFrozen(map[string]string{"de": "Germany", "fr": "France", "jp": "Japan"})
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = f.Get("fr")
+		_ = f.Exists("xx")
+	})
+
+	assert.Zero(t, allocs)
+}
+
+func TestFrozenRangeAndMap(t *testing.T) {
+	m := map[int]string{1: "a", 2: "b", 3: "c"}
+	f := gomap.MustBuildFrozen(m)
+
+	visited := make(map[int]string)
+	f.Range(func(k int, v string) bool {
+		visited[k] = v
+		return true
+	})
+
+	assert.Equal(t, m, visited)
+	assert.Equal(t, m, f.Map().MapCopy())
+
+	stopped := 0
+	f.Range(func(int, string) bool {
+		stopped++
+		return false
+	})
+	assert.Equal(t, 1, stopped)
+}
+
+func TestFrozenIsNotChangedBySource(t *testing.T) {
+	m := map[string]int{"a": 1}
+	f := gomap.MustBuildFrozen(m)
+
+	m["a"] = 2
+	m["b"] = 3
+
+	v, _ := f.Get("a")
+	assert.Equal(t, 1, v)
+	assert.False(t, f.Exists("b"))
+}
+
+func TestMustBuildFrozenPanicsOnCollision(t *testing.T) {
+	assert.Panics(t, func() {
+		gomap.MustBuildFrozen(map[constStringer]int{1: 1, 2: 2})
+	})
+}
@@ -0,0 +1,121 @@
+package gomap
+
+import "context"
+
+type future[V any] struct {
+	value   V
+	set     bool
+	done    chan struct{}
+	waiters int
+}
+
+// FutureMap is a concurrency safe map whose readers can wait until some goroutine sets the key,
+// e.g. to correlate the requests with the asynchronous responses.
+type FutureMap[K comparable, V any] struct {
+	futures Map[K, *future[V]]
+}
+
+// NewFutureMap creates the empty FutureMap[K, V].
+func NewFutureMap[K comparable, V any]() FutureMap[K, V] {
+	return FutureMap[K, V]{futures: From(map[K]*future[V]{})}
+}
+
+// Set sets the value by key and wakes up the goroutines waiting for it.
+func (f FutureMap[K, V]) Set(k K, v V) FutureMap[K, V] {
+	f.set(k, v, false)
+
+	return f
+}
+
+// set stores the value and return false if the key was already set and overwrite is not allowed.
+func (f FutureMap[K, V]) set(k K, v V, once bool) bool {
+	m := f.futures
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	fut, exists := m.innerMap[k]
+	if !exists {
+		fut = &future[V]{done: make(chan struct{})}
+		m.innerMap[k] = fut
+	}
+
+	if fut.set {
+		if once {
+			return false
+		}
+
+		// The waiters of the previous value are already woken up, so the value is replaced together with its channel.
+		m.innerMap[k] = &future[V]{value: v, set: true, done: closedChan}
+
+		return true
+	}
+
+	fut.value, fut.set = v, true
+	close(fut.done)
+
+	return true
+}
+
+var closedChan = func() chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+
+	return ch
+}()
+
+// Get return the value by key without waiting.
+func (f FutureMap[K, V]) Get(k K) (V, bool) {
+	m := f.futures
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if fut, exists := m.innerMap[k]; exists && fut.set {
+		return fut.value, true
+	}
+
+	var v V
+	return v, false
+}
+
+// GetWait return the value by key, blocking until the key is set or the ctx is done.
+// The key that was never set is removed when its last waiter gives up.
+func (f FutureMap[K, V]) GetWait(ctx context.Context, k K) (V, error) {
+	m := f.futures
+
+	m.mutex.Lock()
+	fut, exists := m.innerMap[k]
+	if !exists {
+		fut = &future[V]{done: make(chan struct{})}
+		m.innerMap[k] = fut
+	}
+	fut.waiters++
+	m.mutex.Unlock()
+
+	select {
+	case <-fut.done:
+		return fut.value, nil
+	case <-ctx.Done():
+	}
+
+	m.mutex.Lock()
+	fut.waiters--
+	if fut.waiters == 0 && !fut.set && m.innerMap[k] == fut {
+		delete(m.innerMap, k)
+	}
+	m.mutex.Unlock()
+
+	var v V
+	return v, ctx.Err()
+}
+
+// Delete deletes the key. The goroutines already waiting for it keep waiting until their ctx is done.
+func (f FutureMap[K, V]) Delete(k K) bool {
+	return f.futures.Delete(k)
+}
+
+// Len return the number of keys, including the ones only being waited for.
+func (f FutureMap[K, V]) Len() int {
+	return f.futures.Len()
+}
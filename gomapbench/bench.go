@@ -0,0 +1,174 @@
+// Package gomapbench runs the configurable read/write mix against the concurrent map engines
+// and prints the comparison table, so the engine can be chosen by data rather than by guess.
+package gomapbench
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/kafkiansky/gomap"
+)
+
+// Engine is the concurrent map under benchmark.
+type Engine interface {
+	Name() string
+	Load(k int) (int, bool)
+	Store(k, v int)
+}
+
+// Config describes the workload.
+type Config struct {
+	// Goroutines is the number of concurrent workers, 8 by default.
+	Goroutines int
+	// OpsPerGoroutine is the number of operations each worker performs, 100000 by default.
+	OpsPerGoroutine int
+	// ReadRatio is the share of reads in range [0, 1], the rest are writes.
+	ReadRatio float64
+	// Keys is the size of the key space, 10000 by default.
+	Keys int
+	// Seed makes the generated workload reproducible.
+	Seed int64
+}
+
+// Result is the measurement of one Engine.
+type Result struct {
+	Engine   string
+	Ops      int
+	Duration time.Duration
+}
+
+// OpsPerSecond return the throughput of the Engine.
+func (r Result) OpsPerSecond() float64 {
+	return float64(r.Ops) / r.Duration.Seconds()
+}
+
+// Run runs the workload against each engine, prefilled with the whole key space.
+func Run(cfg Config, engines ...Engine) []Result {
+	if cfg.Goroutines <= 0 {
+		cfg.Goroutines = 8
+	}
+
+	if cfg.OpsPerGoroutine <= 0 {
+		cfg.OpsPerGoroutine = 100000
+	}
+
+	if cfg.Keys <= 0 {
+		cfg.Keys = 10000
+	}
+
+	results := make([]Result, 0, len(engines))
+	for _, engine := range engines {
+		results = append(results, run(cfg, engine))
+	}
+
+	return results
+}
+
+func run(cfg Config, engine Engine) Result {
+	for k := 0; k < cfg.Keys; k++ {
+		engine.Store(k, k)
+	}
+
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+
+	for g := 0; g < cfg.Goroutines; g++ {
+		r := rand.New(rand.NewSource(cfg.Seed + int64(g)))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+
+			for i := 0; i < cfg.OpsPerGoroutine; i++ {
+				k := r.Intn(cfg.Keys)
+
+				if r.Float64() < cfg.ReadRatio {
+					engine.Load(k)
+				} else {
+					engine.Store(k, i)
+				}
+			}
+		}()
+	}
+
+	began := time.Now()
+	close(start)
+	wg.Wait()
+
+	return Result{
+		Engine:   engine.Name(),
+		Ops:      cfg.Goroutines * cfg.OpsPerGoroutine,
+		Duration: time.Since(began),
+	}
+}
+
+// WriteTable writes the results to w as the aligned table.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "ENGINE\tOPS\tDURATION\tOPS/SEC\tNS/OP")
+	for _, r := range results {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%.0f\t%.1f\n",
+			r.Engine, r.Ops, r.Duration.Round(time.Microsecond), r.OpsPerSecond(), float64(r.Duration.Nanoseconds())/float64(r.Ops))
+	}
+
+	return tw.Flush()
+}
+
+// Map adapts gomap.Map to Engine.
+func Map() Engine {
+	return mapEngine{m: gomap.New[int, int]()}
+}
+
+type mapEngine struct {
+	m gomap.Map[int, int]
+}
+
+func (mapEngine) Name() string             { return "gomap.Map" }
+func (e mapEngine) Load(k int) (int, bool) { return e.m.Get(k) }
+func (e mapEngine) Store(k, v int)         { e.m.Add(k, v) }
+
+// ShardedMap adapts gomap.ShardedMap with the shards to Engine.
+func ShardedMap(shards int) Engine {
+	return shardedMapEngine{m: gomap.NewShardedMap[int, int](shards)}
+}
+
+type shardedMapEngine struct {
+	m gomap.ShardedMap[int, int]
+}
+
+func (shardedMapEngine) Name() string             { return "gomap.ShardedMap" }
+func (e shardedMapEngine) Load(k int) (int, bool) { return e.m.Get(k) }
+func (e shardedMapEngine) Store(k, v int)         { e.m.Add(k, v) }
+
+// SyncMap adapts sync.Map to Engine.
+func SyncMap() Engine {
+	return &syncMapEngine{}
+}
+
+type syncMapEngine struct {
+	m sync.Map
+}
+
+func (*syncMapEngine) Name() string { return "sync.Map" }
+
+func (e *syncMapEngine) Load(k int) (int, bool) {
+	v, ok := e.m.Load(k)
+	if !ok {
+		return 0, false
+	}
+
+	return v.(int), true
+}
+
+func (e *syncMapEngine) Store(k, v int) { e.m.Store(k, v) }
+
+// Engines return all the built-in engines.
+func Engines() []Engine {
+	return []Engine{Map(), ShardedMap(32), SyncMap()}
+}
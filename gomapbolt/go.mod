@@ -0,0 +1,12 @@
+module github.com/kafkiansky/gomap/gomapbolt
+
+go 1.20
+
+require (
+	github.com/kafkiansky/gomap v0.0.0
+	go.etcd.io/bbolt v1.3.9
+)
+
+require golang.org/x/sys v0.4.0 // indirect
+
+replace github.com/kafkiansky/gomap => ../
@@ -0,0 +1,162 @@
+// Package gomapbolt provides the gomap.Store over the embedded bbolt database, so the store-backed maps,
+// e.g. with the offsets, the cursors or the dedupe sets, survive the restart.
+// The package is the separate module to keep bbolt out of the gomap dependencies.
+package gomapbolt
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/kafkiansky/gomap"
+	bolt "go.etcd.io/bbolt"
+)
+
+// DefaultBucket is the bucket used when WithBucket is not set.
+const DefaultBucket = "gomap"
+
+var _ gomap.Store = (*Store)(nil)
+
+// Store is the gomap.Store over one bucket of the bbolt database.
+type Store struct {
+	db     *bolt.DB
+	bucket []byte
+}
+
+// Option configures the Store.
+type Option func(*Store)
+
+// WithBucket sets the bucket of the Store, several Stores can share one database with the different buckets.
+func WithBucket(name string) Option {
+	return func(s *Store) {
+		s.bucket = []byte(name)
+	}
+}
+
+// NewStore creates the Store over the opened database and creates its bucket if it does not exist.
+// The database is owned by the caller and must be closed after the Store is not used.
+func NewStore(db *bolt.DB, opts ...Option) (*Store, error) {
+	s := &Store{
+		db:     db,
+		bucket: []byte(DefaultBucket),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(s.bucket)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Open opens or creates the database file at path and the Store over it. The database is closed by Store.Close.
+func Open(path string, opts ...Option) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewStore(db, opts...)
+	if err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	return s, nil
+}
+
+// Close closes the underlying database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Get return the value by key and false if the key does not exist.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+
+	var (
+		value  []byte
+		exists bool
+	)
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		// the value is valid only within the transaction, so it is copied.
+		if v := tx.Bucket(s.bucket).Get([]byte(key)); v != nil {
+			value, exists = append([]byte{}, v...), true
+		}
+
+		return nil
+	})
+
+	return value, exists, err
+}
+
+// Set stores the value by key, the write is durable when Set returns.
+func (s *Store) Set(ctx context.Context, key string, value []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Put([]byte(key), value)
+	})
+}
+
+// Delete deletes the key, deleting the missing key is not an error.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(s.bucket).Delete([]byte(key))
+	})
+}
+
+// Scan calls fn for each key with the prefix in the byte order until fn returns false.
+// The keys are read in one read transaction, so fn sees the consistent snapshot and must not write to the Store.
+func (s *Store) Scan(ctx context.Context, prefix string, fn func(key string) bool) error {
+	return s.db.View(func(tx *bolt.Tx) error {
+		p := []byte(prefix)
+		c := tx.Bucket(s.bucket).Cursor()
+
+		for k, _ := c.Seek(p); k != nil && bytes.HasPrefix(k, p); k, _ = c.Next() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			if !fn(string(k)) {
+				return nil
+			}
+		}
+
+		return nil
+	})
+}
+
+// SetMany stores all the values in one transaction, so either all of them are stored or none.
+func (s *Store) SetMany(ctx context.Context, values map[string][]byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(s.bucket)
+		for key, value := range values {
+			if err := b.Put([]byte(key), value); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
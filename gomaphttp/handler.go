@@ -0,0 +1,223 @@
+// Package gomaphttp provides the http.Handler exposing the introspection of gomap.Registry, e.g. at /debug/gomap.
+package gomaphttp
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/kafkiansky/gomap"
+)
+
+// Handler serves the JSON description of the Maps registered in gomap.Registry.
+type Handler struct {
+	registry *gomap.Registry
+	mux      *http.ServeMux
+	auth     func(*http.Request) bool
+}
+
+// Option configures the Handler.
+type Option func(*Handler)
+
+// WithAdmin enables the administration endpoints, which are served only to the requests accepted by auth:
+//
+//	GET    /keys?map=name&key=key  returns the value stored by the key
+//	DELETE /keys?map=name&key=key  deletes the key
+//	POST   /clear?map=name         removes all the elements of the map
+//	GET    /export?map=name        streams the elements in the format of gomap.Map.Export
+//	POST   /import?map=name        adds the elements streamed in the request body
+func WithAdmin(auth func(*http.Request) bool) Option {
+	return func(h *Handler) {
+		h.auth = auth
+	}
+}
+
+// BearerToken accepts the requests with "Authorization: Bearer <token>" header, the token without the scheme is rejected.
+func BearerToken(token string) func(*http.Request) bool {
+	return func(r *http.Request) bool {
+		provided, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+		return ok && token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+	}
+}
+
+// NewHandler creates the Handler for the registry.
+func NewHandler(registry *gomap.Registry, opts ...Option) *Handler {
+	h := &Handler{
+		registry: registry,
+		mux:      http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("/", h.stats)
+
+	if h.auth != nil {
+		h.mux.HandleFunc("/keys", h.admin(h.keys))
+		h.mux.HandleFunc("/clear", h.admin(h.clear))
+		h.mux.HandleFunc("/export", h.admin(h.export))
+		h.mux.HandleFunc("/import", h.admin(h.importMap))
+	}
+
+	return h
+}
+
+// ServeHTTP implements http.Handler. Mount it with http.StripPrefix when serving under a sub path.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+type statsResponse struct {
+	Maps []gomap.RegistryStats `json:"maps"`
+}
+
+func (h *Handler) stats(w http.ResponseWriter, r *http.Request) {
+	// the pattern "/" matches every path not matched by the other patterns.
+	if r.URL.Path != "/" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, statsResponse{Maps: h.registry.Stats()})
+}
+
+func (h *Handler) admin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !h.auth(r) {
+			writeError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+type keyResponse struct {
+	Map   string `json:"map"`
+	Key   string `json:"key"`
+	Value any    `json:"value,omitempty"`
+	Found bool   `json:"found"`
+}
+
+func (h *Handler) keys(w http.ResponseWriter, r *http.Request) {
+	name, key := r.URL.Query().Get("map"), r.URL.Query().Get("key")
+
+	switch r.Method {
+	case http.MethodGet:
+		v, found, err := h.registry.Lookup(name, key)
+		if err != nil {
+			writeRegistryError(w, err)
+			return
+		}
+
+		status := http.StatusOK
+		if !found {
+			status = http.StatusNotFound
+		}
+
+		writeJSON(w, status, keyResponse{Map: name, Key: key, Value: v, Found: found})
+	case http.MethodDelete:
+		found, err := h.registry.DeleteKey(name, key)
+		if err != nil {
+			writeRegistryError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, keyResponse{Map: name, Key: key, Found: found})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (h *Handler) clear(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err := h.registry.Clear(r.URL.Query().Get("map")); err != nil {
+		writeRegistryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	name := r.URL.Query().Get("map")
+	if !h.registered(name) {
+		writeError(w, http.StatusNotFound, "map is not registered")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+
+	// The status is already sent once streaming starts, so the client detects failures by the truncated body.
+	_ = h.registry.Export(name, w)
+}
+
+func (h *Handler) importMap(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if err := h.registry.Import(r.URL.Query().Get("map"), r.Body); err != nil {
+		if errors.Is(err, gomap.ErrNotRegistered) {
+			writeRegistryError(w, err)
+			return
+		}
+
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) registered(name string) bool {
+	for _, n := range h.registry.Names() {
+		if n == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeRegistryError(w http.ResponseWriter, err error) {
+	if errors.Is(err, gomap.ErrNotRegistered) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	writeError(w, http.StatusInternalServerError, err.Error())
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, errorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
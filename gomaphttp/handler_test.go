@@ -0,0 +1,74 @@
+package gomaphttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaphttp"
+)
+
+func newHandler(t *testing.T) http.Handler {
+	registry := gomap.NewRegistry()
+
+	m, err := gomap.GetOrCreate[string, int](registry, "users")
+	require.NoError(t, err)
+	m.Add("a", 1)
+
+	return gomaphttp.NewHandler(registry, gomaphttp.WithAdmin(gomaphttp.BearerToken("secret")))
+}
+
+func serve(h http.Handler, method, target, authorization string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, nil)
+	if authorization != "" {
+		r.Header.Set("Authorization", authorization)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	return w
+}
+
+func TestHandlerStats(t *testing.T) {
+	h := newHandler(t)
+
+	w := serve(h, http.MethodGet, "/", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"users"`)
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/keyz", "").Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(h, http.MethodPost, "/", "").Code)
+}
+
+func TestHandlerBearerToken(t *testing.T) {
+	h := newHandler(t)
+
+	for authorization, status := range map[string]int{
+		"Bearer secret": http.StatusOK,
+		"secret":        http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"Basic secret":  http.StatusUnauthorized,
+		"":              http.StatusUnauthorized,
+	} {
+		w := serve(h, http.MethodGet, "/keys?map=users&key=a", authorization)
+		assert.Equal(t, status, w.Code, authorization)
+	}
+}
+
+func TestHandlerAdmin(t *testing.T) {
+	h := newHandler(t)
+
+	w := serve(h, http.MethodGet, "/keys?map=users&key=a", "Bearer secret")
+	assert.JSONEq(t, `{"map":"users","key":"a","value":1,"found":true}`, w.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/keys?map=users&key=b", "Bearer secret").Code)
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/keys?map=missing&key=a", "Bearer secret").Code)
+
+	assert.Equal(t, http.StatusOK, serve(h, http.MethodDelete, "/keys?map=users&key=a", "Bearer secret").Code)
+	assert.Equal(t, http.StatusNotFound, serve(h, http.MethodGet, "/keys?map=users&key=a", "Bearer secret").Code)
+}
@@ -0,0 +1,250 @@
+// Package gomapredis provides the gomap.Store over Redis, so the store-backed maps can use Redis as the shared storage.
+// The package is the separate module to keep the Redis client out of the gomap dependencies.
+package gomapredis
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/redis/go-redis/v9"
+)
+
+var _ gomap.Store = (*Store)(nil)
+
+// Store is the gomap.Store over Redis.
+type Store struct {
+	client    redis.UniversalClient
+	ttl       time.Duration
+	scanCount int64
+}
+
+// Option configures the Store.
+type Option func(*Store)
+
+// WithTTL sets the expiration of the stored keys, zero (the default) stores the keys without expiration.
+func WithTTL(ttl time.Duration) Option {
+	return func(s *Store) {
+		s.ttl = ttl
+	}
+}
+
+// WithScanCount sets the COUNT hint of SCAN, 100 by default.
+func WithScanCount(count int64) Option {
+	return func(s *Store) {
+		s.scanCount = count
+	}
+}
+
+// NewStore creates the Store over the client, which can be the single node, the cluster or the sentinel client.
+func NewStore(client redis.UniversalClient, opts ...Option) *Store {
+	s := &Store{
+		client:    client,
+		scanCount: 100,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Get return the value by key and false if the key does not exist.
+func (s *Store) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := s.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Set stores the value by key.
+func (s *Store) Set(ctx context.Context, key string, value []byte) error {
+	return s.client.Set(ctx, key, value, s.ttl).Err()
+}
+
+// Delete deletes the key, deleting the missing key is not an error.
+func (s *Store) Delete(ctx context.Context, key string) error {
+	return s.client.Del(ctx, key).Err()
+}
+
+// Scan calls fn for each key with the prefix until fn returns false.
+// The keys are iterated by SCAN, so the key added or deleted during the scan may be visited or not.
+// On the cluster client every master is scanned, fn is never called concurrently.
+func (s *Store) Scan(ctx context.Context, prefix string, fn func(key string) bool) error {
+	match := escapePattern(prefix) + "*"
+
+	cluster, ok := s.client.(*redis.ClusterClient)
+	if !ok {
+		_, err := s.scan(ctx, s.client, match, fn)
+		return err
+	}
+
+	// ForEachMaster scans the masters concurrently and keeps only the first error, so fn is serialized,
+	// the stop cancels the scans of the other masters and the first real error is recorded separately.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mutex    sync.Mutex
+		stopped  bool
+		firstErr error
+	)
+
+	err := cluster.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+		proceed, err := s.scan(ctx, master, match, func(key string) bool {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if stopped {
+				return false
+			}
+
+			if !fn(key) {
+				stopped = true
+				cancel()
+			}
+
+			return !stopped
+		})
+
+		if err != nil {
+			mutex.Lock()
+			// the scans canceled by the stop fail with context.Canceled, which is not an error of Scan.
+			if !stopped && firstErr == nil {
+				firstErr = err
+			}
+			mutex.Unlock()
+
+			return err
+		}
+
+		if !proceed {
+			return errStopScan
+		}
+
+		return nil
+	})
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	if stopped || errors.Is(err, errStopScan) {
+		return nil
+	}
+
+	return err
+}
+
+var errStopScan = errors.New("gomapredis: scan stopped")
+
+// scan iterates the keys of one node and return false if fn stopped it.
+func (s *Store) scan(ctx context.Context, client redis.Cmdable, match string, fn func(key string) bool) (bool, error) {
+	var cursor uint64
+	for {
+		keys, next, err := client.Scan(ctx, cursor, match, s.scanCount).Result()
+		if err != nil {
+			return false, err
+		}
+
+		for _, key := range keys {
+			if !fn(key) {
+				return false, nil
+			}
+		}
+
+		if next == 0 {
+			return true, nil
+		}
+
+		cursor = next
+	}
+}
+
+// GetMany return the values of the keys found in Redis, fetched by pipelined GETs in one round trip per node,
+// since MGET of the keys from the different slots fails on the cluster.
+func (s *Store) GetMany(ctx context.Context, keys ...string) (map[string][]byte, error) {
+	if len(keys) == 0 {
+		return map[string][]byte{}, nil
+	}
+
+	cmds := make([]*redis.StringCmd, len(keys))
+
+	// the error of the pipeline is the first failed command, which is redis.Nil for the missing key, so the commands are checked one by one.
+	_, _ = s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			cmds[i] = pipe.Get(ctx, key)
+		}
+
+		return nil
+	})
+
+	found := make(map[string][]byte, len(keys))
+	for i, cmd := range cmds {
+		value, err := cmd.Bytes()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		found[keys[i]] = value
+	}
+
+	return found, nil
+}
+
+// SetMany stores all the values in one pipelined round trip.
+func (s *Store) SetMany(ctx context.Context, values map[string][]byte) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range values {
+			pipe.Set(ctx, key, value, s.ttl)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+// DeleteMany deletes all the keys in one pipelined round trip.
+func (s *Store) DeleteMany(ctx context.Context, keys ...string) error {
+	_, err := s.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range keys {
+			pipe.Del(ctx, key)
+		}
+
+		return nil
+	})
+
+	return err
+}
+
+func escapePattern(prefix string) string {
+	var b strings.Builder
+	for _, r := range prefix {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteByte('\\')
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package gomapredis_test
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap/gomapredis"
+)
+
+func newStores(t *testing.T) (*miniredis.Miniredis, map[string]*gomapredis.Store) {
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cluster := redis.NewClusterClient(&redis.ClusterOptions{Addrs: []string{mr.Addr()}})
+
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = cluster.Close()
+	})
+
+	return mr, map[string]*gomapredis.Store{
+		"client":  gomapredis.NewStore(client, gomapredis.WithScanCount(2)),
+		"cluster": gomapredis.NewStore(cluster, gomapredis.WithScanCount(2)),
+	}
+}
+
+func TestStore(t *testing.T) {
+	_, stores := newStores(t)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, found, err := store.Get(ctx, name+":a")
+			require.NoError(t, err)
+			assert.False(t, found)
+
+			require.NoError(t, store.Set(ctx, name+":a", []byte("1")))
+
+			v, found, err := store.Get(ctx, name+":a")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, []byte("1"), v)
+
+			require.NoError(t, store.Delete(ctx, name+":a"))
+			require.NoError(t, store.Delete(ctx, name+":a"), "deleting the missing key is not an error")
+
+			_, found, err = store.Get(ctx, name+":a")
+			require.NoError(t, err)
+			assert.False(t, found)
+		})
+	}
+}
+
+func TestStoreBatches(t *testing.T) {
+	_, stores := newStores(t)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// the keys hash to the different cluster slots, which MGET would reject.
+			require.NoError(t, store.SetMany(ctx, map[string][]byte{
+				name + ":a": []byte("1"),
+				name + ":b": []byte("2"),
+				name + ":c": []byte("3"),
+			}))
+
+			found, err := store.GetMany(ctx, name+":a", name+":b", name+":missing")
+			require.NoError(t, err)
+			assert.Equal(t, map[string][]byte{name + ":a": []byte("1"), name + ":b": []byte("2")}, found)
+
+			require.NoError(t, store.DeleteMany(ctx, name+":a", name+":b", name+":c"))
+
+			found, err = store.GetMany(ctx, name+":a", name+":b", name+":c")
+			require.NoError(t, err)
+			assert.Empty(t, found)
+		})
+	}
+}
+
+func TestStoreScan(t *testing.T) {
+	_, stores := newStores(t)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for _, key := range []string{"a", "b", "c", "d", "e"} {
+				require.NoError(t, store.Set(ctx, name+":scan:"+key, nil))
+			}
+			require.NoError(t, store.Set(ctx, name+":other", nil))
+
+			var keys []string
+			require.NoError(t, store.Scan(ctx, name+":scan:", func(key string) bool {
+				keys = append(keys, key)
+				return true
+			}))
+
+			sort.Strings(keys)
+			assert.Equal(t, []string{
+				name + ":scan:a", name + ":scan:b", name + ":scan:c", name + ":scan:d", name + ":scan:e",
+			}, keys)
+
+			visited := 0
+			require.NoError(t, store.Scan(ctx, name+":scan:", func(string) bool {
+				visited++
+				return visited < 2
+			}))
+			assert.Equal(t, 2, visited, "the scan stops when fn returns false")
+		})
+	}
+}
+
+func TestStoreScanEscapesPattern(t *testing.T) {
+	_, stores := newStores(t)
+	store := stores["client"]
+	ctx := context.Background()
+
+	require.NoError(t, store.Set(ctx, "a*:1", nil))
+	require.NoError(t, store.Set(ctx, "ab:1", nil))
+
+	var keys []string
+	require.NoError(t, store.Scan(ctx, "a*:", func(key string) bool {
+		keys = append(keys, key)
+		return true
+	}))
+
+	assert.Equal(t, []string{"a*:1"}, keys)
+}
+
+func TestStoreScanError(t *testing.T) {
+	mr, stores := newStores(t)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			mr.SetError("injected")
+			defer mr.SetError("")
+
+			err := store.Scan(context.Background(), "", func(string) bool { return true })
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStoreTTL(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	store := gomapredis.NewStore(client, gomapredis.WithTTL(time.Minute))
+	require.NoError(t, store.Set(context.Background(), "a", []byte("1")))
+
+	mr.FastForward(2 * time.Minute)
+
+	_, found, err := store.Get(context.Background(), "a")
+	require.NoError(t, err)
+	assert.False(t, found)
+}
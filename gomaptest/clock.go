@@ -0,0 +1,39 @@
+package gomaptest
+
+import (
+	"sync"
+	"time"
+)
+
+// FakeClock is the gomap.Clock which only moves when it's told to.
+type FakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+// NewFakeClock creates the FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now return the current time of FakeClock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// Advance moves the FakeClock forward by d.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	c.now = c.now.Add(d)
+	c.mutex.Unlock()
+}
+
+// Set moves the FakeClock to now.
+func (c *FakeClock) Set(now time.Time) {
+	c.mutex.Lock()
+	c.now = now
+	c.mutex.Unlock()
+}
@@ -0,0 +1,78 @@
+package gomaptest
+
+import (
+	"math/rand"
+	"reflect"
+
+	"github.com/kafkiansky/gomap"
+)
+
+// Generator produces random Maps for property-based tests.
+type Generator[K comparable, V any] struct {
+	// Key generates a random key.
+	Key func(*rand.Rand) K
+	// Value generates a random value.
+	Value func(*rand.Rand) V
+	// MinSize is the minimal number of elements attempted, zero by default. Negative MinSize is treated as zero.
+	MinSize int
+	// MaxSize is the maximal number of elements attempted, 50 by default.
+	// The generated Map may contain fewer elements when the generated keys repeat.
+	MaxSize int
+}
+
+// Map generates the random Map[K, V] using r.
+func (g Generator[K, V]) Map(r *rand.Rand) gomap.Map[K, V] {
+	minSize, maxSize := g.MinSize, g.MaxSize
+	if maxSize <= 0 {
+		maxSize = 50
+	}
+
+	if minSize < 0 {
+		minSize = 0
+	}
+
+	if minSize > maxSize {
+		minSize = maxSize
+	}
+
+	size := minSize + r.Intn(maxSize-minSize+1)
+
+	m := make(map[K]V, size)
+	for i := 0; i < size; i++ {
+		m[g.Key(r)] = g.Value(r)
+	}
+
+	return gomap.From(m)
+}
+
+// FromSeed generates the Map[K, V] deterministically from seed,
+// which allows to draw Maps from the frameworks providing only the random integers, e.g. rapid.Custom.
+func (g Generator[K, V]) FromSeed(seed int64) gomap.Map[K, V] {
+	return g.Map(rand.New(rand.NewSource(seed)))
+}
+
+// Values is compatible with quick.Config.Values and fills every argument with the generated Map[K, V].
+func (g Generator[K, V]) Values(args []reflect.Value, r *rand.Rand) {
+	for i := range args {
+		args[i] = reflect.ValueOf(g.Map(r))
+	}
+}
+
+// Ints generates the random int in [0, n).
+func Ints(n int) func(*rand.Rand) int {
+	return func(r *rand.Rand) int {
+		return r.Intn(n)
+	}
+}
+
+// Strings generates the random lowercase string with length up to maxLen.
+func Strings(maxLen int) func(*rand.Rand) string {
+	return func(r *rand.Rand) string {
+		b := make([]byte, r.Intn(maxLen+1))
+		for i := range b {
+			b[i] = byte('a' + r.Intn(26))
+		}
+
+		return string(b)
+	}
+}
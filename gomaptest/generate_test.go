@@ -0,0 +1,52 @@
+package gomaptest_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestGeneratorSize(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, tc := range []struct {
+		minSize, maxSize int
+		want             [2]int
+	}{
+		{minSize: -5, maxSize: 3, want: [2]int{0, 3}},
+		{minSize: 10, maxSize: 3, want: [2]int{3, 3}},
+		{minSize: 2, maxSize: 4, want: [2]int{2, 4}},
+	} {
+		g := gomaptest.Generator[int, int]{
+			Key:     gomaptest.Ints(1 << 30),
+			Value:   gomaptest.Ints(10),
+			MinSize: tc.minSize,
+			MaxSize: tc.maxSize,
+		}
+
+		for i := 0; i < 100; i++ {
+			m := g.Map(r)
+			assert.GreaterOrEqual(t, m.Len(), tc.want[0])
+			assert.LessOrEqual(t, m.Len(), tc.want[1])
+		}
+	}
+}
+
+func TestGeneratorFromSeed(t *testing.T) {
+	g := gomaptest.Generator[string, int]{Key: gomaptest.Strings(8), Value: gomaptest.Ints(100)}
+
+	assert.Equal(t, g.FromSeed(42).MapCopy(), g.FromSeed(42).MapCopy())
+}
+
+func TestGeneratorNegativeMinSize(t *testing.T) {
+	g := gomaptest.Generator[int, int]{Key: gomaptest.Ints(1 << 30), Value: gomaptest.Ints(10), MaxSize: 3}
+	negative := g
+	negative.MinSize = -5
+
+	for seed := int64(0); seed < 100; seed++ {
+		assert.Equal(t, g.FromSeed(seed).MapCopy(), negative.FromSeed(seed).MapCopy())
+	}
+}
@@ -0,0 +1,122 @@
+package gomaptest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/kafkiansky/gomap"
+)
+
+var update = flag.Bool("gomaptest.update", false, "rewrite the golden files instead of comparing against them")
+
+// SaveGolden writes the canonical serialization of Map[K, V] to the golden file at path.
+func SaveGolden[K comparable, V any](t testing.TB, path string, m gomap.Map[K, V]) {
+	t.Helper()
+
+	golden, err := canonical(m)
+	if err != nil {
+		t.Fatalf("serialize map: %v", err)
+	}
+
+	b, err := json.MarshalIndent(golden, "", "  ")
+	if err != nil {
+		t.Fatalf("serialize map: %v", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("create golden dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, append(b, '\n'), 0o644); err != nil {
+		t.Fatalf("write golden file: %v", err)
+	}
+}
+
+// CompareGolden compares Map[K, V] with the golden file at path and reports the added, removed and changed keys.
+// When the test binary runs with -gomaptest.update flag, the golden file is rewritten instead.
+func CompareGolden[K comparable, V any](t testing.TB, path string, m gomap.Map[K, V]) bool {
+	t.Helper()
+
+	if *update {
+		SaveGolden(t, path, m)
+		return true
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+
+	var expected map[string]json.RawMessage
+	if err := json.Unmarshal(b, &expected); err != nil {
+		t.Fatalf("decode golden file %s: %v", path, err)
+	}
+
+	for k, v := range expected {
+		var compacted bytes.Buffer
+		if err := json.Compact(&compacted, v); err == nil {
+			expected[k] = compacted.Bytes()
+		}
+	}
+
+	actual, err := canonical(m)
+	if err != nil {
+		t.Fatalf("serialize map: %v", err)
+	}
+
+	if diff := compareRaw(expected, actual); !diff.Empty() {
+		t.Errorf("map does not match golden file %s:\n%s", path, diff)
+		return false
+	}
+
+	return true
+}
+
+func canonical[K comparable, V any](m gomap.Map[K, V]) (map[string]json.RawMessage, error) {
+	entries := m.ToSlice(nil)
+
+	golden := make(map[string]json.RawMessage, len(entries))
+	for _, e := range entries {
+		b, err := json.Marshal(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("key %+v: %w", e.Key, err)
+		}
+
+		golden[fmt.Sprintf("%v", e.Key)] = b
+	}
+
+	return golden, nil
+}
+
+func compareRaw(expected, actual map[string]json.RawMessage) Diff {
+	var diff Diff
+
+	for k, ev := range expected {
+		av, exists := actual[k]
+
+		switch {
+		case !exists:
+			diff.Removed = append(diff.Removed, fmt.Sprintf("%s: %s", k, ev))
+		case !bytes.Equal(ev, av):
+			diff.Changed = append(diff.Changed, fmt.Sprintf("%s: %s => %s", k, ev, av))
+		}
+	}
+
+	for k, av := range actual {
+		if _, exists := expected[k]; !exists {
+			diff.Added = append(diff.Added, fmt.Sprintf("%s: %s", k, av))
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+
+	return diff
+}
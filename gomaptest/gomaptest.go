@@ -0,0 +1,138 @@
+// Package gomaptest provides helpers for testing the code built on gomap.Map.
+package gomaptest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/kafkiansky/gomap"
+)
+
+// AssertContains checks that the Map[K, V] contains the key k with the value v.
+func AssertContains[K comparable, V any](t testing.TB, m gomap.Map[K, V], k K, v V) bool {
+	t.Helper()
+
+	actual, exists := m.Get(k)
+	if !exists {
+		t.Errorf("key %+v not found in %s", k, String(m))
+		return false
+	}
+
+	if !reflect.DeepEqual(actual, v) {
+		t.Errorf("key %+v: expected %+v, got %+v", k, v, actual)
+		return false
+	}
+
+	return true
+}
+
+// AssertSubset checks that every element of the subset is present in the Map[K, V] with the equal value.
+func AssertSubset[K comparable, V any](t testing.TB, m, subset gomap.Map[K, V]) bool {
+	t.Helper()
+
+	diff := Compare(subset, m)
+	diff.Added = nil
+
+	if !diff.Empty() {
+		t.Errorf("not a subset:\n%s", diff)
+		return false
+	}
+
+	return true
+}
+
+// AssertEqualMaps checks that both maps contain the same elements and reports the difference otherwise.
+func AssertEqualMaps[K comparable, V any](t testing.TB, expected, actual gomap.Map[K, V]) bool {
+	t.Helper()
+
+	if diff := Compare(expected, actual); !diff.Empty() {
+		t.Errorf("maps are not equal:\n%s", diff)
+		return false
+	}
+
+	return true
+}
+
+// String return the deterministic rendering of Map[K, V], sorted by key.
+func String[K comparable, V any](m gomap.Map[K, V]) string {
+	return m.DumpString()
+}
+
+// Diff describes the difference between two maps by their formatted keys.
+type Diff struct {
+	// Added contains the keys present only in the actual map.
+	Added []string
+	// Removed contains the keys present only in the expected map.
+	Removed []string
+	// Changed contains the keys present in both maps with the different values.
+	Changed []string
+}
+
+// Empty reports whether the maps are equal.
+func (d Diff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String return the diff as one line per key, prefixed by +, - or ~.
+func (d Diff) String() string {
+	var sb strings.Builder
+
+	for _, group := range []struct {
+		prefix string
+		lines  []string
+	}{{"+", d.Added}, {"-", d.Removed}, {"~", d.Changed}} {
+		for _, line := range group.lines {
+			sb.WriteString(group.prefix)
+			sb.WriteString(" ")
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// Compare return the difference between the expected and the actual maps.
+func Compare[K comparable, V any](expected, actual gomap.Map[K, V]) Diff {
+	var diff Diff
+
+	exp := toBuiltin(expected)
+	act := toBuiltin(actual)
+
+	for k, ev := range exp {
+		av, exists := act[k]
+
+		switch {
+		case !exists:
+			diff.Removed = append(diff.Removed, fmt.Sprintf("%+v: %+v", k, ev))
+		case !reflect.DeepEqual(ev, av):
+			diff.Changed = append(diff.Changed, fmt.Sprintf("%+v: %+v => %+v", k, ev, av))
+		}
+	}
+
+	for k, av := range act {
+		if _, exists := exp[k]; !exists {
+			diff.Added = append(diff.Added, fmt.Sprintf("%+v: %+v", k, av))
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+
+	return diff
+}
+
+func toBuiltin[K comparable, V any](m gomap.Map[K, V]) map[K]V {
+	entries := m.ToSlice(nil)
+
+	builtin := make(map[K]V, len(entries))
+	for _, e := range entries {
+		builtin[e.Key] = e.Value
+	}
+
+	return builtin
+}
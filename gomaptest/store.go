@@ -0,0 +1,193 @@
+package gomaptest
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kafkiansky/gomap"
+)
+
+// ErrInjected is returned by MemoryStore when the fault is injected and Faults.Err is not set.
+var ErrInjected = errors.New("gomaptest: injected store fault")
+
+var _ gomap.Store = (*MemoryStore)(nil)
+
+// Faults describes the faults MemoryStore injects into every operation.
+type Faults struct {
+	// Latency delays every operation, the delay is interrupted by the context.
+	Latency time.Duration
+	// ErrorRate is the probability from 0 to 1 the operation fails with Err.
+	ErrorRate float64
+	// Err is the error of the failed operation, ErrInjected by default.
+	Err error
+	// Seed seeds the random source of ErrorRate, so the failures are reproducible.
+	Seed int64
+}
+
+type memoryEntry struct {
+	key   string
+	value []byte
+}
+
+// MemoryStore is the in-memory gomap.Store for testing the code written against the store-backed maps
+// without the real infrastructure. It evicts the least recently used keys over the capacity
+// and injects the configured Faults.
+type MemoryStore struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+	faults   Faults
+	rand     *rand.Rand
+}
+
+// NewMemoryStore creates the empty MemoryStore keeping up to capacity keys, zero capacity means no limit.
+func NewMemoryStore(capacity int) *MemoryStore {
+	return &MemoryStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// SetFaults replaces the injected Faults, the zero Faults disables the injection.
+func (s *MemoryStore) SetFaults(faults Faults) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if faults.Err == nil {
+		faults.Err = ErrInjected
+	}
+
+	s.faults = faults
+	s.rand = rand.New(rand.NewSource(faults.Seed))
+}
+
+// Get return the value by key and false if the key does not exist.
+func (s *MemoryStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, false, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	e, exists := s.entries[key]
+	if !exists {
+		return nil, false, nil
+	}
+
+	s.lru.MoveToFront(e)
+
+	return append([]byte{}, e.Value.(*memoryEntry).value...), true, nil
+}
+
+// Set stores the copy of the value by key, evicting the least recently used key over the capacity.
+func (s *MemoryStore) Set(ctx context.Context, key string, value []byte) error {
+	if err := s.inject(ctx); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	value = append([]byte{}, value...)
+
+	if e, exists := s.entries[key]; exists {
+		e.Value.(*memoryEntry).value = value
+		s.lru.MoveToFront(e)
+
+		return nil
+	}
+
+	s.entries[key] = s.lru.PushFront(&memoryEntry{key: key, value: value})
+
+	if s.capacity > 0 && s.lru.Len() > s.capacity {
+		oldest := s.lru.Back()
+		s.lru.Remove(oldest)
+		delete(s.entries, oldest.Value.(*memoryEntry).key)
+	}
+
+	return nil
+}
+
+// Delete deletes the key, deleting the missing key is not an error.
+func (s *MemoryStore) Delete(ctx context.Context, key string) error {
+	if err := s.inject(ctx); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if e, exists := s.entries[key]; exists {
+		s.lru.Remove(e)
+		delete(s.entries, key)
+	}
+
+	return nil
+}
+
+// Scan calls fn for each key with the prefix in the sorted order until fn returns false.
+func (s *MemoryStore) Scan(ctx context.Context, prefix string, fn func(key string) bool) error {
+	if err := s.inject(ctx); err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	keys := make([]string, 0, len(s.entries))
+	for key := range s.entries {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	s.mutex.Unlock()
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !fn(key) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Len return the number of stored keys.
+func (s *MemoryStore) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.entries)
+}
+
+func (s *MemoryStore) inject(ctx context.Context) error {
+	s.mutex.Lock()
+	faults := s.faults
+	failed := faults.ErrorRate > 0 && s.rand.Float64() < faults.ErrorRate
+	s.mutex.Unlock()
+
+	if faults.Latency > 0 {
+		timer := time.NewTimer(faults.Latency)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if failed {
+		return faults.Err
+	}
+
+	return ctx.Err()
+}
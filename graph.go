@@ -0,0 +1,175 @@
+package gomap
+
+import "fmt"
+
+// Graph is a concurrency safe directed graph stored as the adjacency Map[K, Set[K]].
+type Graph[K comparable] struct {
+	edges Map[K, Set[K]]
+}
+
+// NewGraph creates the empty Graph[K].
+func NewGraph[K comparable]() Graph[K] {
+	return Graph[K]{edges: From(map[K]Set[K]{})}
+}
+
+// AddNode adds the node without edges, if it does not exist yet.
+func (g Graph[K]) AddNode(k K) Graph[K] {
+	g.node(k)
+
+	return g
+}
+
+// AddEdge adds the directed edge from -> to, adding the missing nodes.
+func (g Graph[K]) AddEdge(from, to K) Graph[K] {
+	g.node(to)
+	g.node(from).Add(to)
+
+	return g
+}
+
+// RemoveEdge removes the directed edge from -> to.
+func (g Graph[K]) RemoveEdge(from, to K) bool {
+	if neighbors, exists := g.edges.Get(from); exists {
+		return neighbors.Delete(to)
+	}
+
+	return false
+}
+
+// HasNode check if the node exists in Graph[K].
+func (g Graph[K]) HasNode(k K) bool {
+	return g.edges.Exists(k)
+}
+
+// Nodes return the nodes of Graph[K].
+func (g Graph[K]) Nodes() []K {
+	return g.edges.Keys()
+}
+
+// Neighbors return the nodes reachable from k by one edge.
+func (g Graph[K]) Neighbors(k K) []K {
+	if neighbors, exists := g.edges.Get(k); exists {
+		return neighbors.Values()
+	}
+
+	return nil
+}
+
+// BFS visits the nodes reachable from start in breadth-first order, until the visit returns false.
+func (g Graph[K]) BFS(start K, visit func(K) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	seen := map[K]struct{}{start: {}}
+	queue := []K{start}
+
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+
+		if !visit(k) {
+			return
+		}
+
+		for _, next := range g.Neighbors(k) {
+			if _, ok := seen[next]; !ok {
+				seen[next] = struct{}{}
+				queue = append(queue, next)
+			}
+		}
+	}
+}
+
+// DFS visits the nodes reachable from start in depth-first order, until the visit returns false.
+func (g Graph[K]) DFS(start K, visit func(K) bool) {
+	if !g.HasNode(start) {
+		return
+	}
+
+	seen := make(map[K]struct{})
+	stack := []K{start}
+
+	for len(stack) > 0 {
+		k := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, ok := seen[k]; ok {
+			continue
+		}
+
+		seen[k] = struct{}{}
+
+		if !visit(k) {
+			return
+		}
+
+		for _, next := range g.Neighbors(k) {
+			if _, ok := seen[next]; !ok {
+				stack = append(stack, next)
+			}
+		}
+	}
+}
+
+// TopoSort return the nodes ordered so that every edge goes from the earlier node to the later one.
+// ErrCycle is returned if Graph[K] has a cycle.
+func (g Graph[K]) TopoSort() ([]K, error) {
+	adjacency := make(map[K][]K)
+	indegree := make(map[K]int)
+
+	for _, k := range g.Nodes() {
+		neighbors := g.Neighbors(k)
+		adjacency[k] = neighbors
+
+		if _, exists := indegree[k]; !exists {
+			indegree[k] = 0
+		}
+
+		for _, next := range neighbors {
+			indegree[next]++
+		}
+	}
+
+	var queue []K
+	iterate(indegree, func(k K, degree int) {
+		if degree == 0 {
+			queue = append(queue, k)
+		}
+	})
+
+	sorted := make([]K, 0, len(indegree))
+	for len(queue) > 0 {
+		k := queue[0]
+		queue = queue[1:]
+		sorted = append(sorted, k)
+
+		for _, next := range adjacency[k] {
+			indegree[next]--
+			if indegree[next] == 0 {
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if len(sorted) != len(indegree) {
+		return nil, fmt.Errorf("topological sort of %d nodes: %w", len(indegree), ErrCycle)
+	}
+
+	return sorted, nil
+}
+
+func (g Graph[K]) node(k K) Set[K] {
+	m := g.edges
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	neighbors, exists := m.innerMap[k]
+	if !exists {
+		neighbors = NewSet[K]()
+		m.innerMap[k] = neighbors
+	}
+
+	return neighbors
+}
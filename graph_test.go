@@ -0,0 +1,111 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestGraph(t *testing.T) {
+	g := gomap.NewGraph[string]()
+	g.AddEdge("a", "b").AddEdge("a", "c").AddNode("d")
+
+	assert.True(t, g.HasNode("b"))
+	assert.True(t, g.HasNode("d"))
+	assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, g.Nodes())
+	assert.ElementsMatch(t, []string{"b", "c"}, g.Neighbors("a"))
+	assert.Empty(t, g.Neighbors("d"))
+
+	assert.True(t, g.RemoveEdge("a", "b"))
+	assert.False(t, g.RemoveEdge("a", "b"))
+	assert.False(t, g.RemoveEdge("x", "b"))
+	assert.Equal(t, []string{"c"}, g.Neighbors("a"))
+	assert.True(t, g.HasNode("b"))
+}
+
+func TestGraphBFS(t *testing.T) {
+	g := gomap.NewGraph[int]()
+	g.AddEdge(1, 2).AddEdge(1, 3).AddEdge(2, 4).AddEdge(3, 4).AddEdge(4, 1).AddNode(5)
+
+	var visited []int
+	g.BFS(1, func(k int) bool {
+		visited = append(visited, k)
+		return true
+	})
+
+	require.Len(t, visited, 4)
+	assert.Equal(t, 1, visited[0])
+	assert.ElementsMatch(t, []int{2, 3}, visited[1:3])
+	assert.Equal(t, 4, visited[3])
+
+	visited = nil
+	g.BFS(1, func(k int) bool {
+		visited = append(visited, k)
+		return len(visited) < 2
+	})
+	assert.Len(t, visited, 2)
+
+	g.BFS(42, func(int) bool {
+		t.Fatal("the missing start must not be visited")
+		return true
+	})
+}
+
+func TestGraphDFS(t *testing.T) {
+	g := gomap.NewGraph[int]()
+	g.AddEdge(1, 2).AddEdge(2, 3).AddEdge(3, 1).AddEdge(1, 4)
+
+	var visited []int
+	g.DFS(1, func(k int) bool {
+		visited = append(visited, k)
+		return true
+	})
+
+	require.Len(t, visited, 4)
+	assert.Equal(t, 1, visited[0])
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, visited)
+
+	if visited[1] == 2 {
+		assert.Equal(t, 3, visited[2], "the branch must be visited to the end before the next one")
+	}
+
+	visited = nil
+	g.DFS(1, func(k int) bool {
+		visited = append(visited, k)
+		return false
+	})
+	assert.Equal(t, []int{1}, visited)
+}
+
+func TestGraphTopoSort(t *testing.T) {
+	g := gomap.NewGraph[string]()
+	g.AddEdge("shirt", "tie").AddEdge("tie", "jacket").AddEdge("trousers", "shoes").
+		AddEdge("trousers", "jacket").AddNode("watch")
+
+	sorted, err := g.TopoSort()
+	require.NoError(t, err)
+	require.Len(t, sorted, 6)
+
+	position := make(map[string]int, len(sorted))
+	for i, k := range sorted {
+		position[k] = i
+	}
+
+	for _, from := range g.Nodes() {
+		for _, to := range g.Neighbors(from) {
+			assert.Less(t, position[from], position[to], "%s -> %s", from, to)
+		}
+	}
+}
+
+func TestGraphTopoSortCycle(t *testing.T) {
+	g := gomap.NewGraph[int]()
+	g.AddEdge(1, 2).AddEdge(2, 3).AddEdge(3, 2)
+
+	sorted, err := g.TopoSort()
+	assert.ErrorIs(t, err, gomap.ErrCycle)
+	assert.Nil(t, sorted)
+}
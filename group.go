@@ -0,0 +1,28 @@
+package gomap
+
+// GroupBy groups the values of Map[K, V] by the key derived from each element under the read lock.
+// The order of the values within the group is unspecified, like the iteration order of the Map.
+func GroupBy[K comparable, V any, G comparable](m Map[K, V], key func(K, V) G) Map[G, []V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	groups := make(map[G][]V)
+	iterate(m.innerMap, func(k K, v V) {
+		g := key(k, v)
+		groups[g] = append(groups[g], v)
+	})
+
+	return newMap(groups)
+}
+
+// GroupSlice groups the values of the slice by the key derived from each value, keeping their order within the group.
+func GroupSlice[T any, G comparable](values []T, key func(T) G) Map[G, []T] {
+	groups := make(map[G][]T)
+
+	for _, v := range values {
+		g := key(v)
+		groups[g] = append(groups[g], v)
+	}
+
+	return newMap(groups)
+}
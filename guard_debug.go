@@ -0,0 +1,48 @@
+//go:build gomap_debug
+
+package gomap
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// iterationGuard records the stack traces of the active iterations over the inner map, which do not hold the lock,
+// so the mutation during any of them panics with both stack traces instead of corrupting the result silently.
+// The iterations holding the read lock, e.g. Range, Reduce, Any and Find, are not covered: the mutation from their
+// callback blocks on the write lock before reaching the check, so it still deadlocks instead of panicking.
+type iterationGuard struct {
+	mutex  sync.Mutex
+	next   uint64
+	active map[uint64][]byte
+}
+
+func (g *iterationGuard) begin() func() {
+	stack := debug.Stack()
+
+	g.mutex.Lock()
+	if g.active == nil {
+		g.active = make(map[uint64][]byte)
+	}
+
+	id := g.next
+	g.next++
+	g.active[id] = stack
+	g.mutex.Unlock()
+
+	return func() {
+		g.mutex.Lock()
+		delete(g.active, id)
+		g.mutex.Unlock()
+	}
+}
+
+func (g *iterationGuard) check() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, stack := range g.active {
+		panic(fmt.Sprintf("gomap: Map mutated during iteration\n\nmutation:\n%s\niteration:\n%s", debug.Stack(), stack))
+	}
+}
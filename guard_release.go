@@ -0,0 +1,15 @@
+//go:build !gomap_debug
+
+package gomap
+
+// iterationGuard detects the mutations during the iterations without the lock with the gomap_debug build tag,
+// it's a no-op otherwise. The mutations from the callbacks of Range and the other locked iterations deadlock either way.
+type iterationGuard struct{}
+
+func (*iterationGuard) begin() func() {
+	return noop
+}
+
+func (*iterationGuard) check() {}
+
+func noop() {}
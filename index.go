@@ -0,0 +1,60 @@
+package gomap
+
+import "sort"
+
+// BuildIndex builds the inverted index of Map[K, V], mapping each token of the values to the keys containing it.
+func (m Map[K, V]) BuildIndex(tokenize func(V) []string) Map[string, []K] {
+	index := make(map[string][]K)
+
+	m.mutex.RLock()
+	iterate(m.innerMap, func(k K, v V) {
+		seen := make(map[string]struct{})
+
+		for _, token := range tokenize(v) {
+			if _, dup := seen[token]; dup {
+				continue
+			}
+
+			seen[token] = struct{}{}
+			index[token] = append(index[token], k)
+		}
+	})
+	m.mutex.RUnlock()
+
+	return newMap(index)
+}
+
+// Search return the keys of the inverted index built by BuildIndex, which contain any of the terms,
+// ranked by the number of matched terms.
+func Search[K comparable](index Map[string, []K], terms ...string) []K {
+	hits := make(map[K]int)
+
+	seen := make(map[string]struct{}, len(terms))
+	for _, term := range terms {
+		if _, dup := seen[term]; dup {
+			continue
+		}
+
+		seen[term] = struct{}{}
+
+		keys, _ := index.Get(term)
+		for _, k := range keys {
+			hits[k]++
+		}
+	}
+
+	found := make([]K, 0, len(hits))
+	for k := range hits {
+		found = append(found, k)
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if hits[found[i]] != hits[found[j]] {
+			return hits[found[i]] > hits[found[j]]
+		}
+
+		return lessAny(found[i], found[j])
+	})
+
+	return found
+}
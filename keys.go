@@ -0,0 +1,48 @@
+package gomap
+
+import (
+	"math"
+	"time"
+)
+
+// FloatKey return the key normalizer rounding the floats to the nearest multiple of epsilon, so the values differing
+// by the rounding errors map to the same key. The negative zero is normalized to zero. NaN never equals itself,
+// so it cannot be looked up as the key at all. Use it with WithKeyNormalizer.
+func FloatKey[F ~float32 | ~float64](epsilon F) func(F) F {
+	return func(f F) F {
+		if epsilon > 0 {
+			f = F(math.Round(float64(f/epsilon))) * epsilon
+		}
+
+		if f == 0 {
+			return 0
+		}
+
+		return f
+	}
+}
+
+// TimeKey return the key normalizer truncating the wall clock times in the location, UTC if it's nil,
+// to the resolution since the midnight, e.g. the hour resolution in Europe/Berlin truncates to the Berlin hours,
+// and stripping the monotonic clock reading, so the same instants are equal keys regardless of their zone.
+// The resolutions of a day and longer truncate to the midnight, the non-positive resolution only converts the location.
+// Use it with WithKeyNormalizer.
+func TimeKey(resolution time.Duration, loc *time.Location) func(time.Time) time.Time {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	return func(t time.Time) time.Time {
+		t = t.In(loc).Round(0)
+		if resolution <= 0 {
+			return t
+		}
+
+		// time.Truncate works on the absolute time, so the wall clock since the midnight is truncated instead.
+		y, mo, d := t.Date()
+		wall := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+			time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+
+		return time.Date(y, mo, d, 0, 0, 0, int(wall-wall%resolution), loc)
+	}
+}
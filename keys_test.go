@@ -0,0 +1,35 @@
+package gomap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestTimeKeyTruncatesWallClockInLocation(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no tzdata:", err)
+	}
+
+	day := gomap.TimeKey(24*time.Hour, berlin)
+	assert.True(t, time.Date(2024, 1, 10, 0, 0, 0, 0, berlin).Equal(day(time.Date(2024, 1, 10, 0, 30, 0, 0, berlin))))
+
+	hour := gomap.TimeKey(time.Hour, berlin)
+	assert.True(t, time.Date(2024, 1, 10, 13, 0, 0, 0, berlin).Equal(hour(time.Date(2024, 1, 10, 12, 59, 0, 0, time.UTC))))
+}
+
+func TestTimeKeyNormalizesZones(t *testing.T) {
+	m := gomap.New(gomap.WithKeyNormalizer[time.Time, int](gomap.TimeKey(time.Minute, nil)))
+
+	utc := time.Date(2024, 1, 10, 12, 0, 10, 0, time.UTC)
+	m.Add(utc, 1)
+
+	v, ok := m.Get(utc.In(time.FixedZone("UTC+3", 3*60*60)).Add(20 * time.Second))
+	require.True(t, ok)
+	assert.Equal(t, 1, v)
+}
@@ -0,0 +1,113 @@
+package gomap
+
+import "context"
+
+type keySemaphore struct {
+	slots chan struct{}
+	refs  int
+}
+
+// KeyedLimiter bounds the number of concurrent holders per key, e.g. at most 2 concurrent refreshes per tenant.
+// The state of the key is dropped once it has no holders and no waiters.
+type KeyedLimiter[K comparable] struct {
+	semaphores Map[K, *keySemaphore]
+	limit      int
+}
+
+// NewKeyedLimiter creates the KeyedLimiter[K] allowing up to limit concurrent holders per key.
+func NewKeyedLimiter[K comparable](limit int) KeyedLimiter[K] {
+	if limit < 1 {
+		limit = 1
+	}
+
+	return KeyedLimiter[K]{
+		semaphores: From(map[K]*keySemaphore{}),
+		limit:      limit,
+	}
+}
+
+// AcquireKey blocks until the slot of the key is acquired or the ctx is done.
+// Each successful AcquireKey must be followed by ReleaseKey.
+func (l KeyedLimiter[K]) AcquireKey(ctx context.Context, k K) error {
+	m := l.semaphores
+
+	m.mutex.Lock()
+	sem, exists := m.innerMap[k]
+	if !exists {
+		sem = &keySemaphore{slots: make(chan struct{}, l.limit)}
+		m.innerMap[k] = sem
+	}
+	sem.refs++
+	m.mutex.Unlock()
+
+	select {
+	case sem.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		l.unref(k, sem)
+		return ctx.Err()
+	}
+}
+
+// TryAcquireKey acquires the slot of the key without blocking and reports whether it succeeded.
+func (l KeyedLimiter[K]) TryAcquireKey(k K) bool {
+	m := l.semaphores
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sem, exists := m.innerMap[k]
+	if !exists {
+		sem = &keySemaphore{slots: make(chan struct{}, l.limit)}
+		m.innerMap[k] = sem
+	}
+
+	select {
+	case sem.slots <- struct{}{}:
+		sem.refs++
+		return true
+	default:
+		if sem.refs == 0 {
+			delete(m.innerMap, k)
+		}
+
+		return false
+	}
+}
+
+// ReleaseKey releases the slot of the key acquired before. It panics if the key has no acquired slots.
+func (l KeyedLimiter[K]) ReleaseKey(k K) {
+	sem, exists := l.semaphores.Get(k)
+	if !exists {
+		panic("gomap: ReleaseKey of the key which is not acquired")
+	}
+
+	select {
+	case <-sem.slots:
+	default:
+		panic("gomap: ReleaseKey of the key which is not acquired")
+	}
+
+	l.unref(k, sem)
+}
+
+// InUse return the number of acquired slots of the key.
+func (l KeyedLimiter[K]) InUse(k K) int {
+	if sem, exists := l.semaphores.Get(k); exists {
+		return len(sem.slots)
+	}
+
+	return 0
+}
+
+func (l KeyedLimiter[K]) unref(k K, sem *keySemaphore) {
+	m := l.semaphores
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sem.refs--
+	if sem.refs == 0 && m.innerMap[k] == sem {
+		delete(m.innerMap, k)
+	}
+}
@@ -0,0 +1,198 @@
+package gomap
+
+import (
+	"container/list"
+	"sync"
+)
+
+// LRUOption configures the LRU.
+type LRUOption[K comparable, V any] func(*LRU[K, V])
+
+// WithLRUOnEvict sets the callback called for each element evicted over the capacity or by RemoveOldest,
+// after the lock is released, so it may access the LRU.
+func WithLRUOnEvict[K comparable, V any](onEvict func(K, V)) LRUOption[K, V] {
+	return func(l *LRU[K, V]) {
+		l.onEvict = onEvict
+	}
+}
+
+// LRU is a concurrency safe cache of the fixed capacity, which evicts the least recently used element when it's full.
+// Get and Add make the element the most recently used, Peek does not.
+type LRU[K comparable, V any] struct {
+	mutex    *sync.Mutex
+	capacity int
+	elements map[K]*list.Element
+	recency  *list.List
+	onEvict  func(K, V)
+}
+
+// NewLRU creates the empty LRU[K, V] of the capacity, which must be positive.
+func NewLRU[K comparable, V any](capacity int, opts ...LRUOption[K, V]) LRU[K, V] {
+	if capacity < 1 {
+		panic("gomap: LRU capacity must be positive")
+	}
+
+	l := LRU[K, V]{
+		mutex:    &sync.Mutex{},
+		capacity: capacity,
+		elements: make(map[K]*list.Element, capacity),
+		recency:  list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(&l)
+	}
+
+	return l
+}
+
+// Add adds or replaces the element making it the most recently used and return true if the oldest element was evicted.
+func (l LRU[K, V]) Add(k K, v V) bool {
+	l.mutex.Lock()
+
+	if e, exists := l.elements[k]; exists {
+		e.Value.(*Entry[K, V]).Value = v
+		l.recency.MoveToFront(e)
+		l.mutex.Unlock()
+
+		return false
+	}
+
+	l.elements[k] = l.recency.PushFront(&Entry[K, V]{Key: k, Value: v})
+
+	var evicted *Entry[K, V]
+	if l.recency.Len() > l.capacity {
+		evicted = l.removeOldest()
+	}
+
+	l.mutex.Unlock()
+
+	l.evicted(evicted)
+
+	return evicted != nil
+}
+
+// Get return the value by key making it the most recently used.
+func (l LRU[K, V]) Get(k K) (V, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if e, exists := l.elements[k]; exists {
+		l.recency.MoveToFront(e)
+		return e.Value.(*Entry[K, V]).Value, true
+	}
+
+	var v V
+	return v, false
+}
+
+// Peek return the value by key without changing its recency.
+func (l LRU[K, V]) Peek(k K) (V, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if e, exists := l.elements[k]; exists {
+		return e.Value.(*Entry[K, V]).Value, true
+	}
+
+	var v V
+	return v, false
+}
+
+// RemoveOldest removes the least recently used element and return it, false is returned if the LRU is empty.
+func (l LRU[K, V]) RemoveOldest() (K, V, bool) {
+	l.mutex.Lock()
+	evicted := l.removeOldest()
+	l.mutex.Unlock()
+
+	if evicted == nil {
+		var (
+			k K
+			v V
+		)
+
+		return k, v, false
+	}
+
+	l.evicted(evicted)
+
+	return evicted.Key, evicted.Value, true
+}
+
+// Delete deletes the element by key, the OnEvict callback is not called.
+func (l LRU[K, V]) Delete(k K) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	e, exists := l.elements[k]
+	if exists {
+		l.recency.Remove(e)
+		delete(l.elements, k)
+	}
+
+	return exists
+}
+
+// Len return the number of elements.
+func (l LRU[K, V]) Len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.elements)
+}
+
+// Keys return the keys from the most to the least recently used.
+func (l LRU[K, V]) Keys() []K {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	keys := make([]K, 0, len(l.elements))
+	for e := l.recency.Front(); e != nil; e = e.Next() {
+		keys = append(keys, e.Value.(*Entry[K, V]).Key)
+	}
+
+	return keys
+}
+
+// Map return the copy of the elements as Map[K, V] without changing their recency.
+func (l LRU[K, V]) Map() Map[K, V] {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	copied := make(map[K]V, len(l.elements))
+	for k, e := range l.elements {
+		copied[k] = e.Value.(*Entry[K, V]).Value
+	}
+
+	return newMap(copied)
+}
+
+// Filter return the elements accepted by the filter as Map[K, V] without changing their recency.
+func (l LRU[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
+	return l.Map().Filter(filter)
+}
+
+// Each return the elements with the mapper applied to the values as Map[K, V] without changing their recency.
+func (l LRU[K, V]) Each(mapper func(V) V) Map[K, V] {
+	return l.Map().Each(mapper)
+}
+
+// removeOldest unlinks the least recently used element. The lock must be held.
+func (l LRU[K, V]) removeOldest() *Entry[K, V] {
+	oldest := l.recency.Back()
+	if oldest == nil {
+		return nil
+	}
+
+	l.recency.Remove(oldest)
+	entry := oldest.Value.(*Entry[K, V])
+	delete(l.elements, entry.Key)
+
+	return entry
+}
+
+func (l LRU[K, V]) evicted(entry *Entry[K, V]) {
+	if entry != nil && l.onEvict != nil {
+		l.onEvict(entry.Key, entry.Value)
+	}
+}
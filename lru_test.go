@@ -0,0 +1,79 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	var evicted []string
+	l := gomap.NewLRU[string, int](2, gomap.WithLRUOnEvict(func(k string, _ int) {
+		evicted = append(evicted, k)
+	}))
+
+	assert.False(t, l.Add("a", 1))
+	assert.False(t, l.Add("b", 2))
+
+	_, _ = l.Get("a")
+	assert.True(t, l.Add("c", 3))
+
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.Equal(t, []string{"c", "a"}, l.Keys())
+}
+
+func TestLRUPeekDoesNotChangeRecency(t *testing.T) {
+	l := gomap.NewLRU[string, int](2)
+	l.Add("a", 1)
+	l.Add("b", 2)
+
+	v, ok := l.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+
+	l.Add("c", 3)
+	assert.False(t, l.Map().Exists("a"))
+}
+
+func TestLRUReplaceAndDelete(t *testing.T) {
+	var evicted int
+	l := gomap.NewLRU[string, int](2, gomap.WithLRUOnEvict(func(string, int) { evicted++ }))
+
+	l.Add("a", 1)
+	l.Add("b", 2)
+	assert.False(t, l.Add("a", 10), "replacing the value does not evict")
+
+	v, _ := l.Get("a")
+	assert.Equal(t, 10, v)
+
+	assert.True(t, l.Delete("a"))
+	assert.False(t, l.Delete("a"))
+	assert.Equal(t, 0, evicted, "Delete does not call OnEvict")
+
+	k, v, ok := l.RemoveOldest()
+	assert.True(t, ok)
+	assert.Equal(t, "b", k)
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 1, evicted)
+
+	_, _, ok = l.RemoveOldest()
+	assert.False(t, ok)
+	assert.Equal(t, 0, l.Len())
+}
+
+func TestLRUOnEvictMayAccessLRU(t *testing.T) {
+	var l gomap.LRU[int, int]
+	l = gomap.NewLRU[int, int](1, gomap.WithLRUOnEvict(func(int, int) {
+		_ = l.Len()
+	}))
+
+	l.Add(1, 1)
+	l.Add(2, 2)
+	assert.Equal(t, 1, l.Len())
+}
+
+func TestLRUCapacityMustBePositive(t *testing.T) {
+	assert.Panics(t, func() { gomap.NewLRU[int, int](0) })
+}
@@ -1,65 +1,442 @@
 package gomap
 
-import "sync"
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
 
 // Map is a concurrency safe data structure, which represents a generic builtin map as a Map[K, V].
+// The copies of Map[K, V] share the same state.
+//
+// Filter, FilterValues, FilterKeys, Chunk, Diff, Join and Each iterate without the lock, so they must not run
+// concurrently with the writers. The inner map replaced meanwhile by Reset or Detach, e.g. by StartFlusher,
+// is iterated to the end as it was, the elements added to the new inner map are not seen.
 type Map[K comparable, V any] struct {
-	mutex    *sync.RWMutex
+	*state[K, V]
+}
+
+type state[K comparable, V any] struct {
+	mutex    sync.RWMutex
 	innerMap map[K]V
+	config   *config[K, V]
+	guard    iterationGuard
+	seq      uint64
 }
 
-func newMap[K comparable, V any](m map[K]V) Map[K, V] {
-	return Map[K, V]{
-		mutex:    &sync.RWMutex{},
-		innerMap: m,
+func newMap[K comparable, V any](m map[K]V, opts ...Option[K, V]) Map[K, V] {
+	newmap := Map[K, V]{
+		state: &state[K, V]{innerMap: m, seq: mapSeq.Add(1)},
 	}
+
+	if len(opts) > 0 {
+		newmap.config = &config[K, V]{}
+		for _, opt := range opts {
+			opt(newmap.config)
+		}
+
+		newmap.config.init(m)
+	}
+
+	return newmap
 }
 
-// Add adds the element to Map[K, V].
+// Add adds the element to Map[K, V]. The element rejected by the quota is dropped, use TryAdd to get the error.
 func (m Map[K, V]) Add(k K, v V) Map[K, V] {
+	_ = m.TryAdd(k, v)
+
+	return m
+}
+
+// TryAdd adds the element to Map[K, V] and return ErrQuotaExceeded if the quota rejects it.
+func (m Map[K, V]) TryAdd(k K, v V) error {
 	m.mutex.Lock()
-	m.innerMap[k] = v
-	m.mutex.Unlock()
+	defer m.mutex.Unlock()
+
+	return m.store(k, v)
+}
+
+// AddIf adds the element to Map[K, V] only if the condition is true.
+func (m Map[K, V]) AddIf(condition bool, k K, v V) Map[K, V] {
+	if condition {
+		return m.Add(k, v)
+	}
 
 	return m
 }
 
+// AddUnlessZero adds the element to Map[K, V] only if the value is not the zero value of V.
+func (m Map[K, V]) AddUnlessZero(k K, v V) Map[K, V] {
+	rv := reflect.ValueOf(&v).Elem()
+
+	return m.AddIf(!rv.IsZero(), k, v)
+}
+
+// AddNonNil adds the element to Map[K, V] only if the value is not nil.
+// Values of kinds that cannot be nil are always added.
+func (m Map[K, V]) AddNonNil(k K, v V) Map[K, V] {
+	return m.AddIf(!isNil(v), k, v)
+}
+
+func isNil(v any) bool {
+	rv := reflect.ValueOf(v)
+
+	switch rv.Kind() {
+	case reflect.Invalid:
+		return true
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice, reflect.UnsafePointer:
+		return rv.IsNil()
+	default:
+		return false
+	}
+}
+
 // Delete delete the element from Map[K, V] using key.
 func (m Map[K, V]) Delete(k K) bool {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	k = m.key(k)
+
+	if _, exists := m.innerMap[k]; exists {
+		m.remove(k)
+		return true
+	}
+
+	return false
+}
+
+// Pop deletes the element by key and return its value. The zero value of V and false are returned if it does not exist.
+func (m Map[K, V]) Pop(k K) (V, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	v, exists := m.innerMap[k]
+	if exists {
+		m.remove(k)
+	}
+
+	return v, exists
+}
+
+// Clear deletes all the elements from Map[K, V] under the write lock, or in chunks WithBulkChunkSize.
+func (m Map[K, V]) Clear() {
+	if size := m.chunkSize(); size > 0 {
+		m.clearInBatches(size)
+		return
+	}
+
+	m.mutex.Lock()
+	for k := range m.innerMap {
+		m.remove(k)
+	}
+	m.mutex.Unlock()
+}
+
+// Reset atomically replaces all the elements of Map[K, V] with the builtin map, which is used without copying.
+// The options of Map[K, V] are kept, the quota accounts the new elements.
+func (m Map[K, V]) Reset(newmap map[K]V) {
+	if newmap == nil {
+		newmap = make(map[K]V)
+	}
+
+	m.mutex.Lock()
+	m.innerMap = newmap
+	if m.config != nil {
+		m.config.reset(newmap)
+	}
+	m.mutex.Unlock()
+}
+
+// Detach atomically replaces the inner map with the empty one and return the previous inner map without copying.
+// The ownership of the returned map is transferred to the caller, e.g. to hand the accumulated batch over to the flusher.
+// The options of Map[K, V] are kept, the quota starts counting from zero.
+func (m Map[K, V]) Detach() map[K]V {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	detached := m.innerMap
+	m.innerMap = make(map[K]V)
+
+	if m.config != nil {
+		m.config.reset(m.innerMap)
+	}
+
+	return detached
+}
+
+// findKey finds the key which formats to s, which lets the string based APIs address the keys of any type.
+func (m Map[K, V]) findKey(s string) (K, bool) {
+	if k, ok := any(s).(K); ok {
+		return k, true
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for k := range m.innerMap {
+		if fmt.Sprintf("%v", k) == s {
+			return k, true
+		}
+	}
+
+	var k K
+	return k, false
+}
+
+// Replace replaces the value by key only if the key already exists in Map[K, V].
+func (m Map[K, V]) Replace(k K, v V) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
 	if _, exists := m.innerMap[k]; exists {
-		delete(m.innerMap, k)
+		_ = m.store(k, v)
 		return true
 	}
 
 	return false
 }
 
+// ReplaceAll replaces each value of Map[K, V] in place with the result of the fn under the single write lock,
+// or in chunks WithBulkChunkSize.
+func (m Map[K, V]) ReplaceAll(fn func(K, V) V) Map[K, V] {
+	if size := m.chunkSize(); size > 0 {
+		m.replaceInBatches(m.AppendKeys(nil), size, fn)
+		return m
+	}
+
+	m.mutex.Lock()
+	for k, v := range m.innerMap {
+		_ = m.store(k, fn(k, v))
+	}
+	m.mutex.Unlock()
+
+	return m
+}
+
+// Rename moves the value from the old key to the new key.
+// ErrKeyNotFound is returned if the old key does not exist and ErrKeyExists if the new key is already taken.
+func (m Map[K, V]) Rename(old, new K) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	old, new = m.key(old), m.key(new)
+
+	v, exists := m.innerMap[old]
+	if !exists {
+		return fmt.Errorf("rename %v: %w", old, ErrKeyNotFound)
+	}
+
+	if old == new {
+		return nil
+	}
+
+	if _, exists := m.innerMap[new]; exists {
+		return fmt.Errorf("rename %v to %v: %w", old, new, ErrKeyExists)
+	}
+
+	m.remove(old)
+
+	if err := m.store(new, v); err != nil {
+		_ = m.store(old, v)
+		return fmt.Errorf("rename %v to %v: %w", old, new, err)
+	}
+
+	return nil
+}
+
+// RenameFunc renames every key of Map[K, V] to the key returned from the fn.
+// If two keys are renamed to the same key, ErrKeyExists is returned and Map[K, V] is left unchanged,
+// the same goes for ErrQuotaExceeded if the renamed keys do not fit the quota.
+func (m Map[K, V]) RenameFunc(fn func(K) K) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	renamed := make(map[K]V, len(m.innerMap))
+	for k, v := range m.innerMap {
+		// the collisions are checked between the normalized keys, which are stored.
+		newKey := m.key(fn(k))
+
+		if _, exists := renamed[newKey]; exists {
+			return fmt.Errorf("rename %v to %v: %w", k, newKey, ErrKeyExists)
+		}
+
+		renamed[newKey] = v
+	}
+
+	if err := m.fits(renamed); err != nil {
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	for k := range m.innerMap {
+		m.remove(k)
+	}
+
+	for k, v := range renamed {
+		_ = m.store(k, v)
+	}
+
+	return nil
+}
+
 // Get return the V and the true, if element by K exists in Map[K, V]. Otherwise, the zero value of V and false will return.
 func (m Map[K, V]) Get(k K) (V, bool) {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	k = m.key(k)
+
 	if v, exists := m.innerMap[k]; exists {
+		m.hit(k)
 		return v, true
 	}
 
+	m.miss()
+
 	var v V
 	return v, false
 }
 
+// GetOrSet return the existing value by key and true, otherwise adds the provided value and return it with false.
+func (m Map[K, V]) GetOrSet(k K, v V) (V, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	if existing, exists := m.innerMap[k]; exists {
+		m.hit(k)
+		return existing, true
+	}
+
+	m.miss()
+	_ = m.store(k, v)
+
+	return v, false
+}
+
+// GetOrCompute return the existing value by key, otherwise adds the value returned by fn and return it.
+// The fn is called under the write lock, so the concurrent callers never compute the value twice, and it must not access the Map.
+func (m Map[K, V]) GetOrCompute(k K, fn func() V) V {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	if existing, exists := m.innerMap[k]; exists {
+		m.hit(k)
+		return existing
+	}
+
+	m.miss()
+	v := fn()
+	_ = m.store(k, v)
+
+	return v
+}
+
+// Update applies fn to the current value by key and its existence flag and stores the result under the single write lock.
+// The fn must not access the Map.
+func (m Map[K, V]) Update(k K, fn func(V, bool) V) V {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	v, exists := m.innerMap[k]
+	v = fn(v, exists)
+	_ = m.store(k, v)
+
+	return v
+}
+
+// Swap stores the value by key and return the previous value and whether it existed.
+func (m Map[K, V]) Swap(k K, v V) (previous V, loaded bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	previous, loaded = m.innerMap[k]
+	_ = m.store(k, v)
+
+	return previous, loaded
+}
+
+// CompareAndSwap swaps the old and new values by key if the value stored in the Map[K, V] is equal to old.
+// Like sync.Map, it panics if the values are not comparable, use CompareAndSwapFunc for such V.
+func (m Map[K, V]) CompareAndSwap(k K, old, new V) bool {
+	return m.CompareAndSwapFunc(k, old, new, equalAny[V])
+}
+
+// CompareAndSwapFunc swaps the old and new values by key if the value stored in the Map[K, V] is equal to old by eq.
+func (m Map[K, V]) CompareAndSwapFunc(k K, old, new V, eq func(a, b V) bool) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	if current, exists := m.innerMap[k]; exists && eq(current, old) {
+		return m.store(k, new) == nil
+	}
+
+	return false
+}
+
+// CompareAndDelete deletes the element by key if its value is equal to old.
+// Like sync.Map, it panics if the values are not comparable, use CompareAndDeleteFunc for such V.
+func (m Map[K, V]) CompareAndDelete(k K, old V) bool {
+	return m.CompareAndDeleteFunc(k, old, equalAny[V])
+}
+
+// CompareAndDeleteFunc deletes the element by key if its value is equal to old by eq.
+func (m Map[K, V]) CompareAndDeleteFunc(k K, old V, eq func(a, b V) bool) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	k = m.key(k)
+
+	if current, exists := m.innerMap[k]; exists && eq(current, old) {
+		m.remove(k)
+		return true
+	}
+
+	return false
+}
+
+func equalAny[V any](a, b V) bool {
+	return any(a) == any(b)
+}
+
 // Len return the actual len of inner map.
 func (m Map[K, V]) Len() int {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
 	return len(m.innerMap)
 }
 
+// inner return the inner map for the iterations without the lock. The field is read under the lock,
+// since Reset and Detach replace it concurrently.
+func (m Map[K, V]) inner() map[K]V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.innerMap
+}
+
 // Exists check if value by key exists in Map[K, V].
 func (m Map[K, V]) Exists(k K) bool {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
 
+	k = m.key(k)
+
 	if _, exists := m.innerMap[k]; exists {
 		return true
 	}
@@ -68,8 +445,8 @@ func (m Map[K, V]) Exists(k K) bool {
 }
 
 // From creates the generic Map[K, V] from builtin map.
-func From[K comparable, V any](m map[K]V) Map[K, V] {
-	return newMap(m)
+func From[K comparable, V any](m map[K]V, opts ...Option[K, V]) Map[K, V] {
+	return newMap(m, opts...)
 }
 
 // FromSlice creates Map[int, V] from slice []V.
@@ -84,62 +461,145 @@ func FromSlice[V any](values []V) Map[int, V] {
 }
 
 // M alias to From.
-func M[K comparable, V any](m map[K]V) Map[K, V] {
-	return newMap(m)
+func M[K comparable, V any](m map[K]V, opts ...Option[K, V]) Map[K, V] {
+	return newMap(m, opts...)
 }
 
 // Filter filters both key and value of generic Map[K, V].
 func (m Map[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
-	newmap := make(map[K]V, len(m.innerMap))
+	defer m.guard.begin()()
+	inner := m.inner()
 
-	for k, v := range m.innerMap {
+	newmap := make(map[K]V, len(inner))
+
+	iterate(inner, func(k K, v V) {
 		if filter(k, v) {
 			newmap[k] = v
 		}
-	}
+	})
 
 	return newMap(newmap)
 }
 
 // FilterValues filters only values of generic Map[K, V].
 func (m Map[K, V]) FilterValues(filter func(V) bool) Map[K, V] {
-	newmap := make(map[K]V, len(m.innerMap))
+	defer m.guard.begin()()
+	inner := m.inner()
 
-	for k, v := range m.innerMap {
+	newmap := make(map[K]V, len(inner))
+
+	iterate(inner, func(k K, v V) {
 		if filter(v) {
 			newmap[k] = v
 		}
-	}
+	})
 
 	return newMap(newmap)
 }
 
 // FilterKeys filters only keys of generic Map[K, V].
 func (m Map[K, V]) FilterKeys(filter func(K) bool) Map[K, V] {
-	newmap := make(map[K]V, len(m.innerMap))
+	defer m.guard.begin()()
+	inner := m.inner()
 
-	for k, v := range m.innerMap {
+	newmap := make(map[K]V, len(inner))
+
+	iterate(inner, func(k K, v V) {
 		if filter(k) {
 			newmap[k] = v
 		}
-	}
+	})
 
 	return newMap(newmap)
 }
 
+// Any check if any element matches the fn, stopping at the first match. The read lock is held during the iteration.
+func (m Map[K, V]) Any(fn func(K, V) bool) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	found := false
+	iterateUntil(m.innerMap, func(k K, v V) bool {
+		found = fn(k, v)
+		return !found
+	})
+
+	return found
+}
+
+// All check if all the elements match the fn, stopping at the first mismatch. It's true for the empty Map[K, V].
+func (m Map[K, V]) All(fn func(K, V) bool) bool {
+	return !m.Any(func(k K, v V) bool { return !fn(k, v) })
+}
+
+// None check if no element matches the fn, stopping at the first match. It's true for the empty Map[K, V].
+func (m Map[K, V]) None(fn func(K, V) bool) bool {
+	return !m.Any(fn)
+}
+
+// Find return the first element matching the fn in the iteration order, which is unspecified.
+// The zero values and false are returned if no element matches.
+func (m Map[K, V]) Find(fn func(K, V) bool) (K, V, bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var (
+		key   K
+		value V
+		found bool
+	)
+
+	iterateUntil(m.innerMap, func(k K, v V) bool {
+		if fn(k, v) {
+			key, value, found = k, v, true
+		}
+
+		return !found
+	})
+
+	return key, value, found
+}
+
+// FindKey return the first key whose element matches the fn, see Find.
+func (m Map[K, V]) FindKey(fn func(K, V) bool) (K, bool) {
+	k, _, found := m.Find(fn)
+
+	return k, found
+}
+
+// Partition splits Map[K, V] in one pass under the read lock into the elements matching the fn and the rest.
+func (m Map[K, V]) Partition(fn func(K, V) bool) (matched Map[K, V], rest Map[K, V]) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	left, right := make(map[K]V), make(map[K]V)
+	iterate(m.innerMap, func(k K, v V) {
+		if fn(k, v) {
+			left[k] = v
+		} else {
+			right[k] = v
+		}
+	})
+
+	return newMap(left), newMap(right)
+}
+
 // Chunk creates slice of Map[K, V] with provided size.
 func (m Map[K, V]) Chunk(size uint) []Map[K, V] {
+	defer m.guard.begin()()
+	inner := m.inner()
+
 	var maps []Map[K, V]
 
 	chunk := make(map[K]V, size)
-	for k, v := range m.innerMap {
+	iterate(inner, func(k K, v V) {
 		chunk[k] = v
 
 		if uint(len(chunk)) >= size {
 			maps = append(maps, newMap(chunk))
 			chunk = make(map[K]V, size)
 		}
-	}
+	})
 
 	if len(chunk) > 0 {
 		maps = append(maps, newMap(chunk))
@@ -150,9 +610,12 @@ func (m Map[K, V]) Chunk(size uint) []Map[K, V] {
 
 // Diff the items in the Map[K, V] that are not present in the other and return them as new Map[K, V].
 func (m Map[K, V]) Diff(other Map[K, V]) Map[K, V] {
+	defer m.guard.begin()()
+	inner := m.inner()
+
 	differ := make(map[K]V, m.Len())
 
-	for k, v := range m.innerMap {
+	for k, v := range inner {
 		if !other.Exists(k) {
 			differ[k] = v
 		}
@@ -173,9 +636,11 @@ func (m Map[K, V]) Join(others ...Map[K, V]) Map[K, V] {
 	others = append(others, m)
 
 	for _, other := range others {
-		for k, v := range other.innerMap {
+		end := other.guard.begin()
+		for k, v := range other.inner() {
 			joined[k] = v
 		}
+		end()
 	}
 
 	return newMap(joined)
@@ -186,13 +651,48 @@ func Join[K comparable, V any](others ...Map[K, V]) Map[K, V] {
 	return M(map[K]V{}).Join(others...)
 }
 
-// Only return Map[K, V] which contains values only for given keys.
+// JoinSum joins maps together to Map[K, V], summing the values of the same key.
+func JoinSum[K comparable, V Number](others ...Map[K, V]) Map[K, V] {
+	return joinWith(others, func(existing, v V) V { return existing + v })
+}
+
+// JoinConcat joins maps together to Map[K, V], concatenating the strings of the same key in the order of maps.
+func JoinConcat[K comparable, V ~string](others ...Map[K, V]) Map[K, V] {
+	return joinWith(others, func(existing, v V) V { return existing + v })
+}
+
+// JoinAppend joins maps together to Map[K, []V], appending the slices of the same key in the order of maps.
+func JoinAppend[K comparable, V any](others ...Map[K, []V]) Map[K, []V] {
+	return joinWith(others, func(existing, v []V) []V {
+		return append(existing[:len(existing):len(existing)], v...)
+	})
+}
+
+func joinWith[K comparable, V any](others []Map[K, V], merge func(existing, v V) V) Map[K, V] {
+	joined := make(map[K]V)
+
+	for _, other := range others {
+		other.mutex.RLock()
+		for k, v := range other.innerMap {
+			if existing, exists := joined[k]; exists {
+				v = merge(existing, v)
+			}
+
+			joined[k] = v
+		}
+		other.mutex.RUnlock()
+	}
+
+	return newMap(joined)
+}
+
+// Only return Map[K, V] which contains values only for given keys, stored by the normalized keys.
 func (m Map[K, V]) Only(keys ...K) Map[K, V] {
 	newmap := make(map[K]V, len(keys))
 
 	for _, key := range keys {
 		if v, exists := m.Get(key); exists {
-			newmap[key] = v
+			newmap[m.key(key)] = v
 		}
 	}
 
@@ -201,9 +701,12 @@ func (m Map[K, V]) Only(keys ...K) Map[K, V] {
 
 // Each iterate the Map[K, V] and apply the mapper function to each element and output the modified Map[K, V].
 func (m Map[K, V]) Each(mapper func(V) V) Map[K, V] {
-	newmap := make(map[K]V, len(m.innerMap))
+	defer m.guard.begin()()
+	inner := m.inner()
 
-	for k, v := range m.innerMap {
+	newmap := make(map[K]V, len(inner))
+
+	for k, v := range inner {
 		newmap[k] = mapper(v)
 	}
 
@@ -212,16 +715,347 @@ func (m Map[K, V]) Each(mapper func(V) V) Map[K, V] {
 
 // Each iterate the Map[K, V] and apply the mapper function to each element of map and output the new Map[K, E].
 func Each[K comparable, V, E any](m Map[K, V], mapper func(V) E) Map[K, E] {
-	newmap := make(map[K]E, len(m.innerMap))
+	defer m.guard.begin()()
+	inner := m.inner()
 
-	for k, v := range m.innerMap {
+	newmap := make(map[K]E, len(inner))
+
+	for k, v := range inner {
 		newmap[k] = mapper(v)
 	}
 
 	return newMap(newmap)
 }
 
+// Reduce folds the elements of Map[K, V] into the accumulator starting from init under the read lock.
+// The elements are visited in no particular order, so fn should not depend on it. The fn must not modify the Map.
+func Reduce[K comparable, V, A any](m Map[K, V], init A, fn func(A, K, V) A) A {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	acc := init
+	iterate(m.innerMap, func(k K, v V) {
+		acc = fn(acc, k, v)
+	})
+
+	return acc
+}
+
+// Clone return the independent copy of Map[K, V] taken under the read lock, configured with the same options.
+// The values themselves are copied shallowly.
+func (m Map[K, V]) Clone() Map[K, V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	clone := newMap(m.copyInner())
+	if m.config != nil {
+		clone.config = m.config.clone()
+		clone.config.init(clone.innerMap)
+	}
+
+	return clone
+}
+
+// Range calls fn for each element of Map[K, V] until fn returns false, like sync.Map.Range.
+// The read lock is held during the whole iteration, so fn must not modify Map[K, V].
+func (m Map[K, V]) Range(fn func(K, V) bool) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	iterateUntil(m.innerMap, fn)
+}
+
 // Map return the Map[K, V] as builtin map[K]V.
+//
+// Deprecated: the returned map is accessed without the lock, use MapCopy or MapUnsafe instead.
 func (m Map[K, V]) Map() map[K]V {
+	return m.MapUnsafe()
+}
+
+// MapUnsafe return the inner builtin map[K]V of Map[K, V] without copying.
+// Accessing it concurrently with the methods of Map[K, V] is a data race and bypasses the configured options.
+func (m Map[K, V]) MapUnsafe() map[K]V {
 	return m.innerMap
 }
+
+// MapCopy return the copy of the inner builtin map[K]V taken under the read lock.
+func (m Map[K, V]) MapCopy() map[K]V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.copyInner()
+}
+
+// Snapshot return the point-in-time copy of Map[K, V] as builtin map[K]V taken under the read lock.
+// The copy is not affected by the later mutations, so it can be serialized or iterated freely.
+func (m Map[K, V]) Snapshot() map[K]V {
+	return m.MapCopy()
+}
+
+// copyInner copies the inner map. The lock must be held.
+func (m Map[K, V]) copyInner() map[K]V {
+	copied := make(map[K]V, len(m.innerMap))
+	for k, v := range m.innerMap {
+		copied[k] = v
+	}
+
+	return copied
+}
+
+// KeySet return the keys of Map[K, V] as Set[K].
+func (m Map[K, V]) KeySet() Set[K] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make(map[K]struct{}, len(m.innerMap))
+	for k := range m.innerMap {
+		keys[k] = struct{}{}
+	}
+
+	return newSet(keys)
+}
+
+// KeysIn return the keys of Map[K, V] that are present in the other as Set[K].
+func (m Map[K, V]) KeysIn(other Map[K, V]) Set[K] {
+	return m.keysFilteredBy(other, true)
+}
+
+// KeysNotIn return the keys of Map[K, V] that are not present in the other as Set[K].
+func (m Map[K, V]) KeysNotIn(other Map[K, V]) Set[K] {
+	return m.keysFilteredBy(other, false)
+}
+
+func (m Map[K, V]) keysFilteredBy(other Map[K, V], present bool) Set[K] {
+	keys := m.KeySet()
+
+	// The keys are copied first, so the both maps are never locked at the same time.
+	for k := range keys.innerSet {
+		if other.Exists(k) != present {
+			delete(keys.innerSet, k)
+		}
+	}
+
+	return keys
+}
+
+// Pluck iterate the Map[K, V] and collect the result of selector function applied to each value as []T.
+func Pluck[K comparable, V, T any](m Map[K, V], selector func(V) T) []T {
+	return PluckKV(m, func(_ K, v V) T { return selector(v) })
+}
+
+// PluckKV iterate the Map[K, V] and collect the result of selector function applied to each element as []T.
+func PluckKV[K comparable, V, T any](m Map[K, V], selector func(K, V) T) []T {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	plucked := make([]T, 0, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		plucked = append(plucked, selector(k, v))
+	})
+
+	return plucked
+}
+
+// ToSlice return the elements of Map[K, V] as []Entry[K, V] sorted with less function.
+// If less is nil, the entries are returned in no particular order.
+func (m Map[K, V]) ToSlice(less func(a, b Entry[K, V]) bool) []Entry[K, V] {
+	m.mutex.RLock()
+	entries := make([]Entry[K, V], 0, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+	})
+	m.mutex.RUnlock()
+
+	if less != nil {
+		sort.Slice(entries, func(i, j int) bool {
+			return less(entries[i], entries[j])
+		})
+	}
+
+	return entries
+}
+
+// RangeBatch iterates the Map[K, V] under the read lock, passing up to n elements at a time to fn until it returns false.
+// The slice passed to fn is reused between the batches, so fn must not retain it, and must not modify the Map.
+func (m Map[K, V]) RangeBatch(n int, fn func([]Entry[K, V]) bool) {
+	if n < 1 {
+		n = 1
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	batch := make([]Entry[K, V], 0, n)
+	proceed := true
+
+	iterateUntil(m.innerMap, func(k K, v V) bool {
+		batch = append(batch, Entry[K, V]{Key: k, Value: v})
+
+		if len(batch) == n {
+			proceed = fn(batch)
+			batch = batch[:0]
+		}
+
+		return proceed
+	})
+
+	if proceed && len(batch) > 0 {
+		fn(batch)
+	}
+}
+
+// ToSliceByKey return the elements of Map[K, V] as []Entry[K, V] sorted by key in ascending order.
+func ToSliceByKey[K Ordered, V any](m Map[K, V]) []Entry[K, V] {
+	return m.ToSlice(func(a, b Entry[K, V]) bool {
+		return a.Key < b.Key
+	})
+}
+
+// ScanByKey iterates the Map[K, V] in ascending key order and stores the running accumulation of fn by each key,
+// e.g. turns the per-bucket counts into the cumulative distribution.
+func ScanByKey[K Ordered, V, A any](m Map[K, V], init A, fn func(A, K, V) A) Map[K, A] {
+	entries := ToSliceByKey(m)
+
+	scanned := make(map[K]A, len(entries))
+	acc := init
+	for _, e := range entries {
+		acc = fn(acc, e.Key, e.Value)
+		scanned[e.Key] = acc
+	}
+
+	return newMap(scanned)
+}
+
+// Keys return the keys of Map[K, V] taken under the read lock, in no particular order.
+func (m Map[K, V]) Keys() []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0, len(m.innerMap))
+	iterate(m.innerMap, func(k K, _ V) {
+		keys = append(keys, k)
+	})
+
+	return keys
+}
+
+// Values return the values of Map[K, V] taken under the read lock, in no particular order.
+func (m Map[K, V]) Values() []V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	values := make([]V, 0, len(m.innerMap))
+	iterate(m.innerMap, func(_ K, v V) {
+		values = append(values, v)
+	})
+
+	return values
+}
+
+// AppendKeys appends the keys of Map[K, V] to dst and return the extended slice.
+func (m Map[K, V]) AppendKeys(dst []K) []K {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	iterate(m.innerMap, func(k K, _ V) {
+		dst = append(dst, k)
+	})
+
+	return dst
+}
+
+// AppendValues appends the values of Map[K, V] to dst and return the extended slice.
+func (m Map[K, V]) AppendValues(dst []V) []V {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	iterate(m.innerMap, func(_ K, v V) {
+		dst = append(dst, v)
+	})
+
+	return dst
+}
+
+// OnCollision resolves the value for the key k when two elements are mapped to the same key.
+type OnCollision[K comparable, V any] func(k K, existing, incoming V) V
+
+// ReKey iterate the Map[K, V] and index its values by the key returned from the fn as the new Map[K2, V].
+// If several elements are mapped to the same key, the onCollision decides which value is kept.
+// If onCollision is nil, one of the colliding values is kept in no particular order.
+func ReKey[K comparable, V any, K2 comparable](m Map[K, V], fn func(K, V) K2, onCollision OnCollision[K2, V]) Map[K2, V] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	newmap := make(map[K2]V, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		k2 := fn(k, v)
+
+		if existing, exists := newmap[k2]; exists && onCollision != nil {
+			v = onCollision(k2, existing, v)
+		}
+
+		newmap[k2] = v
+	})
+
+	return newMap(newmap)
+}
+
+// MapKeys return the new Map[K2, V] with the keys of Map[K, V] transformed by the fn, e.g. lowercased or prefixed.
+// If several keys are transformed to the same key, one of their values is kept in no particular order.
+func MapKeys[K comparable, K2 comparable, V any](m Map[K, V], fn func(K) K2) Map[K2, V] {
+	return MapEntries(m, func(k K, v V) (K2, V) { return fn(k), v })
+}
+
+// MapEntries return the new Map[K2, V2] with each element of Map[K, V] transformed by the fn.
+// If several elements are transformed to the same key, one of them is kept in no particular order.
+func MapEntries[K comparable, V any, K2 comparable, V2 any](m Map[K, V], fn func(K, V) (K2, V2)) Map[K2, V2] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	mapped := make(map[K2]V2, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		k2, v2 := fn(k, v)
+		mapped[k2] = v2
+	})
+
+	return newMap(mapped)
+}
+
+// Invert return the new Map[V, K] with the keys and the values of Map[K, V] swapped.
+// If several keys hold the same value, one of them is kept in no particular order, use InvertFunc to resolve such collisions.
+func Invert[K, V comparable](m Map[K, V]) Map[V, K] {
+	return InvertFunc(m, nil)
+}
+
+// InvertFunc is Invert, which calls onCollision with the value and both keys when several keys hold the same value,
+// so the collision can be reported and the kept key chosen.
+func InvertFunc[K, V comparable](m Map[K, V], onCollision OnCollision[V, K]) Map[V, K] {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	inverted := make(map[V]K, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		if existing, exists := inverted[v]; exists && onCollision != nil {
+			k = onCollision(v, existing, k)
+		}
+
+		inverted[v] = k
+	})
+
+	return newMap(inverted)
+}
+
+// Unzip return the keys and the values of Map[K, V] as two slices, where the value at index i belongs to the key at index i.
+func (m Map[K, V]) Unzip() ([]K, []V) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	keys := make([]K, 0, len(m.innerMap))
+	values := make([]V, 0, len(m.innerMap))
+	iterate(m.innerMap, func(k K, v V) {
+		keys = append(keys, k)
+		values = append(values, v)
+	})
+
+	return keys, values
+}
@@ -0,0 +1,34 @@
+package gomap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestRenameFuncNormalizedCollision(t *testing.T) {
+	m := gomap.New(gomap.WithKeyNormalizer[string, int](strings.ToLower))
+	m.Add("a", 1).Add("b", 2)
+
+	err := m.RenameFunc(func(k string) string {
+		if k == "a" {
+			return "X"
+		}
+
+		return "x"
+	})
+
+	require.ErrorIs(t, err, gomap.ErrKeyExists)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.MapCopy())
+}
+
+func TestOnlyStoresNormalizedKeys(t *testing.T) {
+	m := gomap.New(gomap.WithKeyNormalizer[string, int](strings.ToLower))
+	m.Add("a", 1).Add("b", 2)
+
+	assert.Equal(t, map[string]int{"a": 1}, m.Only("A").MapCopy())
+}
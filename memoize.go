@@ -0,0 +1,271 @@
+package gomap
+
+import (
+	"context"
+	"time"
+)
+
+// LoadErrorPolicy decides what the memoized function returns when fn fails, see OnLoadError.
+type LoadErrorPolicy int
+
+const (
+	// LoadErrorReturn returns the zero value and the error of fn.
+	LoadErrorReturn LoadErrorPolicy = iota
+	// LoadErrorStale returns the expired result instead of the error, if it's present.
+	// The stale result is kept, so the next call tries fn again.
+	LoadErrorStale
+	// LoadErrorRetry calls fn again with the exponential backoff and returns the error of the last attempt.
+	LoadErrorRetry
+)
+
+// OnLoadError configures the handling of the errors of fn in MemoizeCtxErr.
+type OnLoadError struct {
+	// Policy is applied to the errors and panics of fn, the panics are not retried.
+	Policy LoadErrorPolicy
+	// Retries is the maximal number of the retries of LoadErrorRetry, 3 by default.
+	Retries int
+	// Backoff is the delay before the first retry of LoadErrorRetry, doubled before each next one, 100ms by default.
+	// The retries stop early when ctx of the call is done.
+	Backoff time.Duration
+}
+
+type memoOptions struct {
+	ttl         time.Duration
+	staleness   time.Duration
+	maxSize     int
+	clock       Clock
+	onLoadError OnLoadError
+}
+
+// MemoizeOption configures Memoize and MemoizeCtxErr.
+type MemoizeOption func(*memoOptions)
+
+// WithMemoTTL expires the memoized results after ttl. Zero means the results never expire.
+func WithMemoTTL(ttl time.Duration) MemoizeOption {
+	return func(o *memoOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithMemoStaleness returns the expired result for up to maxStaleness after the TTL while refreshing it
+// in the background (stale-while-revalidate), so the callers do not wait for fn when the result has just expired.
+// The refresh runs once per argument with context.Background, if it fails the stale result is served until the next call
+// retries it. The refresh goroutine lives only for the call of fn, so there is nothing to stop on shutdown.
+// Past maxStaleness the result is computed as if it was missing.
+func WithMemoStaleness(maxStaleness time.Duration) MemoizeOption {
+	return func(o *memoOptions) {
+		o.staleness = maxStaleness
+	}
+}
+
+// WithMemoMaxSize bounds the number of memoized results, evicting an arbitrary one when the limit is reached.
+// Zero means no limit.
+func WithMemoMaxSize(n int) MemoizeOption {
+	return func(o *memoOptions) {
+		o.maxSize = n
+	}
+}
+
+// WithMemoClock sets the Clock used to expire the results, SystemClock by default.
+func WithMemoClock(clock Clock) MemoizeOption {
+	return func(o *memoOptions) {
+		o.clock = clock
+	}
+}
+
+// WithMemoOnLoadError sets the policy applied when fn fails, LoadErrorReturn by default.
+func WithMemoOnLoadError(onLoadError OnLoadError) MemoizeOption {
+	return func(o *memoOptions) {
+		o.onLoadError = onLoadError
+	}
+}
+
+type memoEntry[V any] struct {
+	done      chan struct{}
+	value     V
+	err       error
+	expiresAt time.Time
+	// refreshing is guarded by the lock of the Map.
+	refreshing bool
+}
+
+// Memoize return the function caching the results of fn by argument.
+// The concurrent calls with the same argument wait for the single call of fn.
+func Memoize[K comparable, V any](fn func(K) V, opts ...MemoizeOption) func(K) V {
+	memoized := MemoizeCtxErr(func(_ context.Context, k K) (V, error) {
+		return fn(k), nil
+	}, opts...)
+
+	return func(k K) V {
+		v, _ := memoized(context.Background(), k)
+		return v
+	}
+}
+
+// MemoizeCtxErr return the function caching the successful results of fn by argument, the errors are not cached.
+// The concurrent calls with the same argument wait for the single call of fn, or until their ctx is done.
+func MemoizeCtxErr[K comparable, V any](fn func(context.Context, K) (V, error), opts ...MemoizeOption) func(context.Context, K) (V, error) {
+	o := memoOptions{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.onLoadError.Policy == LoadErrorRetry {
+		fn = retryLoad(fn, o.onLoadError)
+	}
+
+	m := From(map[K]*memoEntry[V]{})
+
+	return func(ctx context.Context, k K) (V, error) {
+		var stale *memoEntry[V]
+
+		m.mutex.Lock()
+		entry, exists := m.innerMap[k]
+		if now := o.clock.Now(); exists && isDone(entry.done) && o.ttl > 0 && !now.Before(entry.expiresAt) {
+			if o.staleness > 0 && now.Before(entry.expiresAt.Add(o.staleness)) {
+				refresh := !entry.refreshing
+				entry.refreshing = true
+				m.mutex.Unlock()
+
+				if refresh {
+					go refreshMemo(m, k, entry, fn, o)
+				}
+
+				return entry.value, entry.err
+			}
+
+			if o.onLoadError.Policy == LoadErrorStale {
+				stale = entry
+			}
+
+			delete(m.innerMap, k)
+			exists = false
+		}
+
+		if !exists {
+			if o.maxSize > 0 && len(m.innerMap) >= o.maxSize {
+				for evicted := range m.innerMap {
+					delete(m.innerMap, evicted)
+					break
+				}
+			}
+
+			entry = &memoEntry[V]{done: make(chan struct{})}
+			m.innerMap[k] = entry
+		}
+		m.mutex.Unlock()
+
+		if !exists {
+			computeMemo(m, k, entry, stale, func() (V, error) { return fn(ctx, k) }, o)
+
+			return entry.value, entry.err
+		}
+
+		select {
+		case <-entry.done:
+			return entry.value, entry.err
+		case <-ctx.Done():
+			var v V
+			return v, ctx.Err()
+		}
+	}
+}
+
+// computeMemo fills the entry and wakes up its waiters. The failed entry is removed, or replaced back by the stale one,
+// so the next call retries, and the panic of fn does not leave the waiters blocked, they get ErrPanicked.
+func computeMemo[K comparable, V any](m Map[K, *memoEntry[V]], k K, entry, stale *memoEntry[V], fn func() (V, error), o memoOptions) {
+	succeeded := false
+
+	defer func() {
+		if !succeeded {
+			m.mutex.Lock()
+			if m.innerMap[k] == entry {
+				if stale != nil {
+					m.innerMap[k] = stale
+				} else {
+					delete(m.innerMap, k)
+				}
+			}
+			m.mutex.Unlock()
+
+			if stale != nil {
+				entry.value, entry.err = stale.value, nil
+			} else {
+				var v V
+				entry.value = v
+			}
+		}
+
+		close(entry.done)
+	}()
+
+	// the waiters get ErrPanicked if fn panics and does not return.
+	entry.err = ErrPanicked
+	entry.value, entry.err = fn()
+	entry.expiresAt = o.clock.Now().Add(o.ttl)
+	succeeded = entry.err == nil
+}
+
+// refreshMemo replaces the stale entry with the fresh result. If fn fails or panics, the stale entry is kept for the next refresh.
+func refreshMemo[K comparable, V any](m Map[K, *memoEntry[V]], k K, stale *memoEntry[V], fn func(context.Context, K) (V, error), o memoOptions) {
+	fresh := &memoEntry[V]{done: make(chan struct{})}
+	close(fresh.done)
+
+	succeeded := false
+
+	defer func() {
+		// the refresh runs in its own goroutine, where the panic of fn would crash the process.
+		_ = recover()
+
+		m.mutex.Lock()
+		if succeeded && m.innerMap[k] == stale {
+			m.innerMap[k] = fresh
+		}
+		stale.refreshing = false
+		m.mutex.Unlock()
+	}()
+
+	fresh.value, fresh.err = fn(context.Background(), k)
+	fresh.expiresAt = o.clock.Now().Add(o.ttl)
+	succeeded = fresh.err == nil
+}
+
+// retryLoad wraps fn to retry its errors as configured by LoadErrorRetry.
+func retryLoad[K comparable, V any](fn func(context.Context, K) (V, error), onLoadError OnLoadError) func(context.Context, K) (V, error) {
+	retries, backoff := onLoadError.Retries, onLoadError.Backoff
+	if retries <= 0 {
+		retries = 3
+	}
+
+	if backoff <= 0 {
+		backoff = 100 * time.Millisecond
+	}
+
+	return func(ctx context.Context, k K) (V, error) {
+		v, err := fn(ctx, k)
+
+		for retry, delay := 0, backoff; err != nil && retry < retries; retry, delay = retry+1, delay*2 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return v, err
+			case <-timer.C:
+			}
+
+			v, err = fn(ctx, k)
+		}
+
+		return v, err
+	}
+}
+
+func isDone(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
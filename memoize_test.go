@@ -0,0 +1,217 @@
+package gomap_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestMemoize(t *testing.T) {
+	var calls atomic.Int32
+
+	square := gomap.Memoize(func(n int) int {
+		calls.Add(1)
+		return n * n
+	})
+
+	assert.Equal(t, 9, square(3))
+	assert.Equal(t, 9, square(3))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestMemoizeErrorsAreNotCached(t *testing.T) {
+	var calls atomic.Int32
+
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int, error) {
+		if calls.Add(1) == 1 {
+			return 0, errors.New("failed")
+		}
+
+		return 1, nil
+	})
+
+	_, err := fn(context.Background(), 1)
+	require.Error(t, err)
+
+	v, err := fn(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v)
+}
+
+func TestMemoizePanicIsReportedToWaiters(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int, error) {
+		close(started)
+		<-release
+		panic("boom")
+	})
+
+	go func() {
+		defer func() { _ = recover() }()
+		_, _ = fn(context.Background(), 1)
+	}()
+
+	<-started
+
+	result := make(chan error)
+	go func() {
+		_, err := fn(context.Background(), 1)
+		result <- err
+	}()
+
+	// the waiter must be blocked on the entry before the computation panics.
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	assert.ErrorIs(t, <-result, gomap.ErrPanicked)
+}
+
+func TestMemoizeStaleness(t *testing.T) {
+	var calls atomic.Int32
+
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int32, error) {
+		return calls.Add(1), nil
+	}, gomap.WithMemoTTL(time.Second), gomap.WithMemoStaleness(time.Second), gomap.WithMemoClock(clock))
+
+	v, _ := fn(context.Background(), 1)
+	assert.Equal(t, int32(1), v)
+
+	clock.Advance(1500 * time.Millisecond)
+
+	v, _ = fn(context.Background(), 1)
+	assert.Equal(t, int32(1), v, "stale value is served while refreshing")
+
+	assert.Eventually(t, func() bool {
+		v, _ := fn(context.Background(), 1)
+		return v == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestMemoizeStalenessRefreshPanicKeepsStaleValue(t *testing.T) {
+	var calls atomic.Int32
+
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int32, error) {
+		n := calls.Add(1)
+		if n == 2 {
+			panic("boom")
+		}
+
+		return n, nil
+	}, gomap.WithMemoTTL(time.Second), gomap.WithMemoStaleness(time.Minute), gomap.WithMemoClock(clock))
+
+	v, _ := fn(context.Background(), 1)
+	assert.Equal(t, int32(1), v)
+
+	clock.Advance(2 * time.Second)
+
+	v, _ = fn(context.Background(), 1)
+	assert.Equal(t, int32(1), v)
+
+	// the failed refresh resets the flag, so the next call refreshes again.
+	assert.Eventually(t, func() bool {
+		v, err := fn(context.Background(), 1)
+		return err == nil && v == 3
+	}, time.Second, time.Millisecond)
+}
+
+func TestMemoizeOnLoadErrorReturnsZero(t *testing.T) {
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int, error) {
+		return 42, errors.New("failed")
+	})
+
+	v, err := fn(context.Background(), 1)
+	assert.Error(t, err)
+	assert.Zero(t, v)
+}
+
+func TestMemoizeOnLoadErrorStale(t *testing.T) {
+	var calls atomic.Int32
+
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	fn := gomap.MemoizeCtxErr(func(_ context.Context, k int) (int32, error) {
+		n := calls.Add(1)
+		if n == 2 || n == 3 || k == 2 {
+			return 0, errors.New("failed")
+		}
+
+		return n, nil
+	}, gomap.WithMemoTTL(time.Second), gomap.WithMemoClock(clock),
+		gomap.WithMemoOnLoadError(gomap.OnLoadError{Policy: gomap.LoadErrorStale}))
+
+	v, err := fn(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), v)
+
+	clock.Advance(2 * time.Second)
+
+	for i := 0; i < 2; i++ {
+		v, err = fn(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), v, "stale value is returned instead of the error")
+	}
+
+	v, err = fn(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), v)
+
+	_, err = fn(context.Background(), 2)
+	assert.Error(t, err, "no stale value to fall back to")
+}
+
+func TestMemoizeOnLoadErrorRetry(t *testing.T) {
+	var calls atomic.Int32
+
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int32, error) {
+		if n := calls.Add(1); n < 3 {
+			return 0, errors.New("failed")
+		}
+
+		return calls.Load(), nil
+	}, gomap.WithMemoOnLoadError(gomap.OnLoadError{Policy: gomap.LoadErrorRetry, Retries: 3, Backoff: time.Millisecond}))
+
+	v, err := fn(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), v)
+}
+
+func TestMemoizeOnLoadErrorRetryGivesUp(t *testing.T) {
+	var calls atomic.Int32
+
+	failed := errors.New("failed")
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int, error) {
+		calls.Add(1)
+		return 0, failed
+	}, gomap.WithMemoOnLoadError(gomap.OnLoadError{Policy: gomap.LoadErrorRetry, Retries: 2, Backoff: time.Millisecond}))
+
+	_, err := fn(context.Background(), 1)
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestMemoizeOnLoadErrorRetryStopsWithContext(t *testing.T) {
+	var calls atomic.Int32
+
+	failed := errors.New("failed")
+	fn := gomap.MemoizeCtxErr(func(context.Context, int) (int, error) {
+		calls.Add(1)
+		return 0, failed
+	}, gomap.WithMemoOnLoadError(gomap.OnLoadError{Policy: gomap.LoadErrorRetry, Backoff: time.Hour}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := fn(ctx, 1)
+	assert.ErrorIs(t, err, failed)
+	assert.Equal(t, int32(1), calls.Load())
+}
@@ -0,0 +1,119 @@
+package gomap
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// EntryMeta is the metadata of the Map element tracked WithMeta.
+type EntryMeta struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	// AccessCount is the number of the reads by Get, GetOrSet and GetOrCompute which found the element.
+	AccessCount int64
+}
+
+type entryMeta struct {
+	createdAt time.Time
+	updatedAt time.Time
+	hits      atomic.Int64
+}
+
+type metaTracker[K comparable] struct {
+	clock   Clock
+	entries map[K]*entryMeta
+	// hits and misses count the reads of the Map, so its hit ratio outlives the deleted elements.
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// WithMeta tracks the creation and update time and the access count of each element, which are available with Map.Meta.
+// The nil clock is SystemClock.
+func WithMeta[K comparable, V any](clock Clock) Option[K, V] {
+	return func(c *config[K, V]) {
+		if clock == nil {
+			clock = SystemClock
+		}
+
+		c.meta = &metaTracker[K]{
+			clock:   clock,
+			entries: make(map[K]*entryMeta),
+		}
+	}
+}
+
+// Meta return the metadata of the element by key, false is returned if it does not exist or the Map is not configured WithMeta.
+func (m Map[K, V]) Meta(k K) (EntryMeta, bool) {
+	if m.config == nil || m.config.meta == nil {
+		return EntryMeta{}, false
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	e, exists := m.config.meta.entries[m.key(k)]
+	if !exists {
+		return EntryMeta{}, false
+	}
+
+	return EntryMeta{
+		CreatedAt:   e.createdAt,
+		UpdatedAt:   e.updatedAt,
+		AccessCount: e.hits.Load(),
+	}, true
+}
+
+// hit counts the access to the existing element. The read lock is enough.
+func (m Map[K, V]) hit(k K) {
+	if m.config == nil || m.config.meta == nil {
+		return
+	}
+
+	if e, exists := m.config.meta.entries[k]; exists {
+		e.hits.Add(1)
+	}
+
+	m.config.meta.hits.Add(1)
+}
+
+// miss counts the read of the missing element. The read lock is enough.
+func (m Map[K, V]) miss() {
+	if m.config != nil && m.config.meta != nil {
+		m.config.meta.misses.Add(1)
+	}
+}
+
+// metaStats return the number of the reads which found the element and which did not, and the creation time
+// of the oldest element. The false is returned if the Map is not configured WithMeta.
+func (m Map[K, V]) metaStats() (hits, misses int64, oldest time.Time, ok bool) {
+	if m.config == nil || m.config.meta == nil {
+		return 0, 0, oldest, false
+	}
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	for _, e := range m.config.meta.entries {
+		if oldest.IsZero() || e.createdAt.Before(oldest) {
+			oldest = e.createdAt
+		}
+	}
+
+	return m.config.meta.hits.Load(), m.config.meta.misses.Load(), oldest, true
+}
+
+// stored records the update of the element. The write lock must be held.
+func (t *metaTracker[K]) stored(k K) {
+	now := t.clock.Now()
+
+	if e, exists := t.entries[k]; exists {
+		e.updatedAt = now
+		return
+	}
+
+	t.entries[k] = &entryMeta{createdAt: now, updatedAt: now}
+}
+
+func (t *metaTracker[K]) removed(k K) {
+	delete(t.entries, k)
+}
@@ -0,0 +1,181 @@
+package gomap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// MigrationStats is the divergence metrics of the MigratingMap.
+type MigrationStats struct {
+	// Reads is the number of Get calls.
+	Reads int64
+	// Fallbacks is the number of reads missed in the new Store and served by the old one.
+	Fallbacks int64
+	// Divergences is the number of verified reads where the new and the old Stores have the different values.
+	Divergences int64
+	// OldWriteErrors is the number of writes which failed in the old Store.
+	OldWriteErrors int64
+}
+
+// MigrationOption configures the MigratingMap.
+type MigrationOption func(*MigratingMap)
+
+// WithMigrationBackfill copies the values read from the old Store by the fallback into the new one.
+// The Store has no set-if-absent, so the backfill racing with the concurrent Set of the same key may overwrite
+// the newer value in the new Store with the old one, until the key is written again. Enable it when the keys
+// being migrated are not written concurrently, or when the stale value is acceptable until its next write.
+func WithMigrationBackfill() MigrationOption {
+	return func(m *MigratingMap) {
+		m.backfill = true
+	}
+}
+
+// WithMigrationVerify reads the old Store on every hit in the new one and counts the different values as the divergences.
+// onDivergence is called with the key of every divergence, it may be nil.
+func WithMigrationVerify(onDivergence func(key string)) MigrationOption {
+	return func(m *MigratingMap) {
+		m.verify = true
+		m.onDivergence = onDivergence
+	}
+}
+
+// MigratingMap is the Store migrating the data from the old Store to the new one, e.g. between the cache engines.
+// It writes to both Stores and reads from the new one with the fallback to the old one,
+// so it can replace the old Store while the new one is being filled and the switch can be rolled back at any moment.
+type MigratingMap struct {
+	oldStore     Store
+	newStore     Store
+	backfill     bool
+	verify       bool
+	onDivergence func(key string)
+
+	reads          atomic.Int64
+	fallbacks      atomic.Int64
+	divergences    atomic.Int64
+	oldWriteErrors atomic.Int64
+}
+
+var _ Store = (*MigratingMap)(nil)
+
+// NewMigratingMap creates the MigratingMap from the old Store to the new one.
+func NewMigratingMap(oldStore, newStore Store, opts ...MigrationOption) *MigratingMap {
+	m := &MigratingMap{oldStore: oldStore, newStore: newStore}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// Get return the value by key from the new Store, or from the old one if the new Store does not have it.
+func (m *MigratingMap) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	m.reads.Add(1)
+
+	value, exists, err := m.newStore.Get(ctx, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("get %s from new store: %w", key, err)
+	}
+
+	if exists {
+		if m.verify {
+			m.compare(ctx, key, value)
+		}
+
+		return value, true, nil
+	}
+
+	value, exists, err = m.oldStore.Get(ctx, key)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+
+	m.fallbacks.Add(1)
+
+	if m.backfill {
+		if err := m.newStore.Set(ctx, key, value); err != nil {
+			return nil, false, fmt.Errorf("backfill %s: %w", key, err)
+		}
+	}
+
+	return value, true, nil
+}
+
+// Set stores the value by key in both Stores. The error of the old Store is returned after the new one is written.
+func (m *MigratingMap) Set(ctx context.Context, key string, value []byte) error {
+	if err := m.newStore.Set(ctx, key, value); err != nil {
+		return fmt.Errorf("set %s in new store: %w", key, err)
+	}
+
+	if err := m.oldStore.Set(ctx, key, value); err != nil {
+		m.oldWriteErrors.Add(1)
+		return fmt.Errorf("set %s in old store: %w", key, err)
+	}
+
+	return nil
+}
+
+// Delete deletes the key from both Stores. The old Store is deleted first, otherwise the concurrent Get would miss
+// in the new Store, fall back to the old one and, WithMigrationBackfill, write the deleted key back to the new one.
+// If the old Store fails, the new one is left untouched, so the key stays consistently readable.
+func (m *MigratingMap) Delete(ctx context.Context, key string) error {
+	if err := m.oldStore.Delete(ctx, key); err != nil {
+		m.oldWriteErrors.Add(1)
+		return fmt.Errorf("delete %s from old store: %w", key, err)
+	}
+
+	if err := m.newStore.Delete(ctx, key); err != nil {
+		return fmt.Errorf("delete %s from new store: %w", key, err)
+	}
+
+	return nil
+}
+
+// Scan calls fn for each key with the prefix in either Store until fn returns false.
+// The keys of the new Store are visited first, the keys existing in both Stores are visited once.
+func (m *MigratingMap) Scan(ctx context.Context, prefix string, fn func(key string) bool) error {
+	seen := make(map[string]struct{})
+	stopped := false
+
+	err := m.newStore.Scan(ctx, prefix, func(key string) bool {
+		seen[key] = struct{}{}
+		stopped = !fn(key)
+
+		return !stopped
+	})
+	if err != nil || stopped {
+		return err
+	}
+
+	return m.oldStore.Scan(ctx, prefix, func(key string) bool {
+		if _, ok := seen[key]; ok {
+			return true
+		}
+
+		return fn(key)
+	})
+}
+
+// Stats return the divergence metrics collected since the MigratingMap was created.
+func (m *MigratingMap) Stats() MigrationStats {
+	return MigrationStats{
+		Reads:          m.reads.Load(),
+		Fallbacks:      m.fallbacks.Load(),
+		Divergences:    m.divergences.Load(),
+		OldWriteErrors: m.oldWriteErrors.Load(),
+	}
+}
+
+// compare counts the divergence if the old Store has the different value. The errors of the old Store are ignored,
+// since the verification must not fail the read served by the new one.
+func (m *MigratingMap) compare(ctx context.Context, key string, value []byte) {
+	old, exists, err := m.oldStore.Get(ctx, key)
+	if err == nil && exists && !bytes.Equal(old, value) {
+		m.divergences.Add(1)
+
+		if m.onDivergence != nil {
+			m.onDivergence(key)
+		}
+	}
+}
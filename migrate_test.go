@@ -0,0 +1,111 @@
+package gomap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestMigratingMapFallback(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	require.NoError(t, oldStore.Set(ctx, "a", []byte("old")))
+
+	m := gomap.NewMigratingMap(oldStore, newStore)
+
+	v, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("old"), v)
+
+	_, ok, _ = newStore.Get(ctx, "a")
+	assert.False(t, ok, "no backfill by default")
+
+	assert.Equal(t, gomap.MigrationStats{Reads: 1, Fallbacks: 1}, m.Stats())
+}
+
+func TestMigratingMapBackfill(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	require.NoError(t, oldStore.Set(ctx, "a", []byte("old")))
+
+	m := gomap.NewMigratingMap(oldStore, newStore, gomap.WithMigrationBackfill())
+
+	_, _, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+
+	v, ok, _ := newStore.Get(ctx, "a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("old"), v)
+}
+
+func TestMigratingMapWritesBothStores(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	m := gomap.NewMigratingMap(oldStore, newStore)
+
+	require.NoError(t, m.Set(ctx, "a", []byte("v")))
+	assert.Equal(t, 1, oldStore.Len())
+	assert.Equal(t, 1, newStore.Len())
+
+	require.NoError(t, m.Delete(ctx, "a"))
+	assert.Equal(t, 0, oldStore.Len())
+	assert.Equal(t, 0, newStore.Len())
+}
+
+func TestMigratingMapDeleteFailingOldStoreKeepsKey(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	m := gomap.NewMigratingMap(oldStore, newStore, gomap.WithMigrationBackfill())
+	require.NoError(t, m.Set(ctx, "a", []byte("v")))
+
+	oldStore.SetFaults(gomaptest.Faults{ErrorRate: 1})
+	require.ErrorIs(t, m.Delete(ctx, "a"), gomaptest.ErrInjected)
+	oldStore.SetFaults(gomaptest.Faults{})
+
+	_, ok, _ := newStore.Get(ctx, "a")
+	assert.True(t, ok, "the new store is not deleted when the old one fails")
+	assert.Equal(t, int64(1), m.Stats().OldWriteErrors)
+}
+
+func TestMigratingMapVerify(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	require.NoError(t, oldStore.Set(ctx, "a", []byte("old")))
+	require.NoError(t, newStore.Set(ctx, "a", []byte("new")))
+
+	var diverged []string
+	m := gomap.NewMigratingMap(oldStore, newStore, gomap.WithMigrationVerify(func(key string) {
+		diverged = append(diverged, key)
+	}))
+
+	v, _, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), v)
+	assert.Equal(t, []string{"a"}, diverged)
+	assert.Equal(t, int64(1), m.Stats().Divergences)
+}
+
+func TestMigratingMapScanVisitsKeysOnce(t *testing.T) {
+	ctx := context.Background()
+	oldStore, newStore := gomaptest.NewMemoryStore(0), gomaptest.NewMemoryStore(0)
+	require.NoError(t, oldStore.Set(ctx, "k:a", nil))
+	require.NoError(t, oldStore.Set(ctx, "k:b", nil))
+	require.NoError(t, newStore.Set(ctx, "k:b", nil))
+	require.NoError(t, newStore.Set(ctx, "k:c", nil))
+
+	m := gomap.NewMigratingMap(oldStore, newStore)
+
+	var keys []string
+	require.NoError(t, m.Scan(ctx, "k:", func(key string) bool {
+		keys = append(keys, key)
+		return true
+	}))
+
+	assert.ElementsMatch(t, []string{"k:a", "k:b", "k:c"}, keys)
+}
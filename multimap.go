@@ -0,0 +1,174 @@
+package gomap
+
+// MultiMap is a concurrency safe map of the keys to the multiple values, kept in the order they are appended.
+type MultiMap[K comparable, V any] struct {
+	values Map[K, []V]
+}
+
+// NewMultiMap creates the empty MultiMap[K, V].
+func NewMultiMap[K comparable, V any]() MultiMap[K, V] {
+	return MultiMap[K, V]{values: From(map[K][]V{})}
+}
+
+// Append appends the values to the key.
+func (mm MultiMap[K, V]) Append(k K, values ...V) MultiMap[K, V] {
+	m := mm.values
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if len(values) > 0 {
+		_ = m.store(k, append(m.innerMap[k], values...))
+	}
+
+	return mm
+}
+
+// GetAll return the copy of the values of the key, nil if the key does not exist.
+func (mm MultiMap[K, V]) GetAll(k K) []V {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	if values, exists := m.innerMap[k]; exists {
+		return append([]V(nil), values...)
+	}
+
+	return nil
+}
+
+// RemoveValue removes the first value of the key equal to v and return true if it was found.
+// The key without values is deleted. Like CompareAndDelete, it panics if the values are not comparable, use RemoveFunc for such V.
+func (mm MultiMap[K, V]) RemoveValue(k K, v V) bool {
+	removed := false
+
+	mm.RemoveFunc(k, func(value V) bool {
+		if !removed && equalAny(value, v) {
+			removed = true
+			return true
+		}
+
+		return false
+	})
+
+	return removed
+}
+
+// RemoveFunc removes the values of the key matching the fn and return the number of removed values.
+// The key without values is deleted.
+func (mm MultiMap[K, V]) RemoveFunc(k K, fn func(V) bool) int {
+	m := mm.values
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	values, exists := m.innerMap[k]
+	if !exists {
+		return 0
+	}
+
+	kept := make([]V, 0, len(values))
+	for _, v := range values {
+		if !fn(v) {
+			kept = append(kept, v)
+		}
+	}
+
+	switch {
+	case len(kept) == len(values):
+	case len(kept) == 0:
+		m.remove(k)
+	default:
+		_ = m.store(k, kept)
+	}
+
+	return len(values) - len(kept)
+}
+
+// Delete deletes the key with all its values.
+func (mm MultiMap[K, V]) Delete(k K) bool {
+	return mm.values.Delete(k)
+}
+
+// Exists check if the key has any values.
+func (mm MultiMap[K, V]) Exists(k K) bool {
+	return mm.values.Exists(k)
+}
+
+// Len return the number of keys.
+func (mm MultiMap[K, V]) Len() int {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.innerMap)
+}
+
+// Count return the number of values of all the keys.
+func (mm MultiMap[K, V]) Count() int {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var count int
+	for _, values := range m.innerMap {
+		count += len(values)
+	}
+
+	return count
+}
+
+// Keys return the keys of MultiMap[K, V] in no particular order.
+func (mm MultiMap[K, V]) Keys() []K {
+	return mm.values.Keys()
+}
+
+// Flatten return all the values as the entries, one per value. The values of the same key are adjacent and in order.
+func (mm MultiMap[K, V]) Flatten() []Entry[K, V] {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var entries []Entry[K, V]
+	iterate(m.innerMap, func(k K, values []V) {
+		for _, v := range values {
+			entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		}
+	})
+
+	return entries
+}
+
+// FlattenValues return all the values of all the keys. The values of the same key are adjacent and in order.
+func (mm MultiMap[K, V]) FlattenValues() []V {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var flattened []V
+	iterate(m.innerMap, func(_ K, values []V) {
+		flattened = append(flattened, values...)
+	})
+
+	return flattened
+}
+
+// Map return the copy of MultiMap[K, V] as Map[K, []V], the slices are copied too.
+func (mm MultiMap[K, V]) Map() Map[K, []V] {
+	m := mm.values
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	copied := make(map[K][]V, len(m.innerMap))
+	for k, values := range m.innerMap {
+		copied[k] = append([]V(nil), values...)
+	}
+
+	return newMap(copied)
+}
@@ -0,0 +1,62 @@
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestMultiMap(t *testing.T) {
+	mm := gomap.NewMultiMap[string, int]()
+	mm.Append("a", 1, 2).Append("a", 3).Append("b", 4)
+
+	assert.Equal(t, []int{1, 2, 3}, mm.GetAll("a"))
+	assert.Nil(t, mm.GetAll("missing"))
+	assert.Equal(t, 2, mm.Len())
+	assert.Equal(t, 4, mm.Count())
+	assert.ElementsMatch(t, []string{"a", "b"}, mm.Keys())
+}
+
+func TestMultiMapGetAllReturnsCopy(t *testing.T) {
+	mm := gomap.NewMultiMap[string, int]()
+	mm.Append("a", 1)
+
+	values := mm.GetAll("a")
+	values[0] = 100
+
+	assert.Equal(t, []int{1}, mm.GetAll("a"))
+}
+
+func TestMultiMapRemove(t *testing.T) {
+	mm := gomap.NewMultiMap[string, int]()
+	mm.Append("a", 1, 2, 1, 3)
+
+	assert.True(t, mm.RemoveValue("a", 1))
+	assert.Equal(t, []int{2, 1, 3}, mm.GetAll("a"))
+	assert.False(t, mm.RemoveValue("a", 5))
+
+	assert.Equal(t, 2, mm.RemoveFunc("a", func(v int) bool { return v < 3 }))
+	assert.Equal(t, []int{3}, mm.GetAll("a"))
+
+	assert.True(t, mm.RemoveValue("a", 3))
+	assert.False(t, mm.Exists("a"), "the key without values is deleted")
+
+	mm.Append("b", 1)
+	assert.True(t, mm.Delete("b"))
+	assert.Equal(t, 0, mm.Len())
+}
+
+func TestMultiMapFlatten(t *testing.T) {
+	mm := gomap.NewMultiMap[string, int]()
+	mm.Append("a", 1, 2)
+
+	assert.Equal(t, []gomap.Entry[string, int]{{Key: "a", Value: 1}, {Key: "a", Value: 2}}, mm.Flatten())
+	assert.Equal(t, []int{1, 2}, mm.FlattenValues())
+
+	copied := mm.Map()
+	values, _ := copied.Get("a")
+	values[0] = 100
+	assert.Equal(t, []int{1, 2}, mm.GetAll("a"))
+}
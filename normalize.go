@@ -0,0 +1,51 @@
+package gomap
+
+// WithKeyNormalizer applies the normalizer to every key stored in or looked up by the Map,
+// so the rules like trimming or lowercasing are enforced in one place. The normalizer must be idempotent.
+// The keys of the builtin map the Map is created from are normalized too, the colliding keys keep one of the values.
+func WithKeyNormalizer[K comparable, V any](normalizer func(K) K) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.keyNormalizer = normalizer
+	}
+}
+
+// WithValueNormalizer applies the normalizer to every value stored in the Map, e.g. to clamp the numbers.
+func WithValueNormalizer[K comparable, V any](normalizer func(V) V) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.valueNormalizer = normalizer
+	}
+}
+
+// key return the normalized key.
+func (m Map[K, V]) key(k K) K {
+	if m.config == nil || m.config.keyNormalizer == nil {
+		return k
+	}
+
+	return m.config.keyNormalizer(k)
+}
+
+// normalize rewrites the builtin map in place with the normalized keys and values.
+func (c *config[K, V]) normalize(m map[K]V) {
+	if c.keyNormalizer == nil && c.valueNormalizer == nil {
+		return
+	}
+
+	entries := make([]Entry[K, V], 0, len(m))
+	for k, v := range m {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		delete(m, k)
+	}
+
+	for _, e := range entries {
+		if c.keyNormalizer != nil {
+			e.Key = c.keyNormalizer(e.Key)
+		}
+
+		if c.valueNormalizer != nil {
+			e.Value = c.valueNormalizer(e.Value)
+		}
+
+		m[e.Key] = e.Value
+	}
+}
@@ -0,0 +1,150 @@
+package gomap
+
+// Option configures the Map created by New, From or M.
+type Option[K comparable, V any] func(*config[K, V])
+
+type config[K comparable, V any] struct {
+	rate            *rateMonitor[K]
+	quota           *quota[K, V]
+	meta            *metaTracker[K]
+	chunkSize       int
+	keyNormalizer   func(K) K
+	valueNormalizer func(V) V
+	cloner          Cloner[V]
+	collate         func(a, b string) int
+}
+
+// New creates the empty Map[K, V] configured with the options.
+func New[K comparable, V any](opts ...Option[K, V]) Map[K, V] {
+	return newMap(make(map[K]V), opts...)
+}
+
+// init normalizes and accounts the elements the Map is created with.
+func (c *config[K, V]) init(m map[K]V) {
+	c.normalize(m)
+
+	if c.quota != nil {
+		for k := range m {
+			c.quota.inserted(k)
+		}
+	}
+
+	if c.meta != nil {
+		for k := range m {
+			c.meta.stored(k)
+		}
+	}
+}
+
+// clone copies the options without the accumulated state.
+func (c *config[K, V]) clone() *config[K, V] {
+	cloned := &config[K, V]{
+		keyNormalizer:   c.keyNormalizer,
+		valueNormalizer: c.valueNormalizer,
+		chunkSize:       c.chunkSize,
+		cloner:          c.cloner,
+		collate:         c.collate,
+	}
+
+	if c.rate != nil {
+		WithMutationRate[K, V](c.rate.MutationRate)(cloned)
+	}
+
+	if c.quota != nil {
+		WithQuota[K, V](c.quota.Quota)(cloned)
+	}
+
+	if c.meta != nil {
+		WithMeta[K, V](c.meta.clock)(cloned)
+	}
+
+	return cloned
+}
+
+// reset accounts the elements replacing all the previous ones.
+func (c *config[K, V]) reset(m map[K]V) {
+	if c.quota != nil {
+		c.quota.reset()
+	}
+
+	if c.meta != nil {
+		c.meta.entries = make(map[K]*entryMeta)
+	}
+
+	c.init(m)
+}
+
+// store sets the element applying the configured options. The write lock must be held.
+func (m Map[K, V]) store(k K, v V) error {
+	m.guard.check()
+
+	if m.config == nil {
+		m.innerMap[k] = v
+		return nil
+	}
+
+	k = m.key(k)
+	if m.config.valueNormalizer != nil {
+		v = m.config.valueNormalizer(v)
+	}
+
+	_, exists := m.innerMap[k]
+	if !exists && m.config.quota != nil {
+		evicted, err := m.config.quota.admit(m.innerMap, k)
+		if err != nil {
+			return err
+		}
+
+		if evicted != nil {
+			m.remove(*evicted)
+		}
+	}
+
+	m.innerMap[k] = v
+
+	if !exists && m.config.quota != nil {
+		m.config.quota.inserted(k)
+	}
+
+	if m.config.meta != nil {
+		m.config.meta.stored(k)
+	}
+
+	if m.config.rate != nil {
+		m.config.rate.record(k)
+	}
+
+	return nil
+}
+
+// remove deletes the existing element applying the configured options. The write lock must be held.
+func (m Map[K, V]) remove(k K) {
+	m.guard.check()
+
+	delete(m.innerMap, k)
+
+	if m.config == nil {
+		return
+	}
+
+	if m.config.quota != nil {
+		m.config.quota.removed(k)
+	}
+
+	if m.config.meta != nil {
+		m.config.meta.removed(k)
+	}
+
+	if m.config.rate != nil {
+		m.config.rate.record(k)
+	}
+}
+
+// fits checks whether the elements would fit the configured quota if they replaced the whole Map.
+func (m Map[K, V]) fits(elements map[K]V) error {
+	if m.config == nil || m.config.quota == nil {
+		return nil
+	}
+
+	return m.config.quota.fits(elements)
+}
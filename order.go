@@ -0,0 +1,40 @@
+package gomap
+
+import "sort"
+
+// iterate calls fn for each element of the builtin map. With the gomap_deterministic build tag
+// the elements are visited sorted by key, so the results depending on the iteration order are reproducible in tests.
+func iterate[K comparable, V any](m map[K]V, fn func(K, V)) {
+	iterateUntil(m, func(k K, v V) bool {
+		fn(k, v)
+		return true
+	})
+}
+
+// iterateUntil is iterate which stops when fn returns false.
+func iterateUntil[K comparable, V any](m map[K]V, fn func(K, V) bool) {
+	if !deterministicOrder {
+		for k, v := range m {
+			if !fn(k, v) {
+				return
+			}
+		}
+
+		return
+	}
+
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return lessAny(keys[i], keys[j])
+	})
+
+	for _, k := range keys {
+		if !fn(k, m[k]) {
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+//go:build gomap_deterministic
+
+package gomap_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestRangeBatchDeterministic(t *testing.T) {
+	m := gomap.New[int, int]()
+	for i := 9; i >= 0; i-- {
+		m.Add(i, i)
+	}
+
+	var keys [][]int
+	m.RangeBatch(4, func(batch []gomap.Entry[int, int]) bool {
+		var ks []int
+		for _, e := range batch {
+			ks = append(ks, e.Key)
+		}
+		keys = append(keys, ks)
+
+		return true
+	})
+
+	assert.Equal(t, [][]int{{0, 1, 2, 3}, {4, 5, 6, 7}, {8, 9}}, keys)
+}
+
+func TestFilterVisitsDeterministically(t *testing.T) {
+	m := gomap.New[int, int]()
+	for i := 9; i >= 0; i-- {
+		m.Add(i, i)
+	}
+
+	var visited []int
+	m.Filter(func(k, _ int) bool {
+		visited = append(visited, k)
+		return true
+	})
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, visited)
+}
@@ -0,0 +1,5 @@
+//go:build !gomap_deterministic
+
+package gomap
+
+const deterministicOrder = false
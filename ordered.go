@@ -0,0 +1,310 @@
+package gomap
+
+import (
+	"bytes"
+	"container/list"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// OrderedMap is a concurrency safe map which preserves the insertion order of the keys for iteration,
+// Keys, Values and JSON marshaling. Replacing the value keeps the key position.
+type OrderedMap[K comparable, V any] struct {
+	mutex    *sync.RWMutex
+	elements map[K]*list.Element
+	order    *list.List
+}
+
+// NewOrderedMap creates the empty OrderedMap[K, V].
+func NewOrderedMap[K comparable, V any]() OrderedMap[K, V] {
+	return OrderedMap[K, V]{
+		mutex:    &sync.RWMutex{},
+		elements: make(map[K]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// OrderedFrom creates the OrderedMap[K, V] from the entries in their order.
+func OrderedFrom[K comparable, V any](entries ...Entry[K, V]) OrderedMap[K, V] {
+	o := NewOrderedMap[K, V]()
+	for _, e := range entries {
+		o.set(e.Key, e.Value)
+	}
+
+	return o
+}
+
+// Add adds the element to the end of OrderedMap[K, V], or replaces the value keeping the position if the key exists.
+func (o OrderedMap[K, V]) Add(k K, v V) OrderedMap[K, V] {
+	o.mutex.Lock()
+	o.set(k, v)
+	o.mutex.Unlock()
+
+	return o
+}
+
+// Get return the value by key.
+func (o OrderedMap[K, V]) Get(k K) (V, bool) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	if e, exists := o.elements[k]; exists {
+		return e.Value.(*Entry[K, V]).Value, true
+	}
+
+	var zero V
+
+	return zero, false
+}
+
+// Delete deletes the element by key.
+func (o OrderedMap[K, V]) Delete(k K) bool {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	e, exists := o.elements[k]
+	if exists {
+		o.order.Remove(e)
+		delete(o.elements, k)
+	}
+
+	return exists
+}
+
+// Exists check if value by key exists in OrderedMap[K, V].
+func (o OrderedMap[K, V]) Exists(k K) bool {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	_, exists := o.elements[k]
+
+	return exists
+}
+
+// Len return the number of elements.
+func (o OrderedMap[K, V]) Len() int {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	return len(o.elements)
+}
+
+// Filter return the new OrderedMap[K, V] with the elements accepted by the filter, in the same order.
+func (o OrderedMap[K, V]) Filter(filter func(K, V) bool) OrderedMap[K, V] {
+	filtered := NewOrderedMap[K, V]()
+
+	o.Range(func(k K, v V) bool {
+		if filter(k, v) {
+			filtered.set(k, v)
+		}
+
+		return true
+	})
+
+	return filtered
+}
+
+// Each return the new OrderedMap[K, V] with the mapper applied to each value, in the same order.
+func (o OrderedMap[K, V]) Each(mapper func(V) V) OrderedMap[K, V] {
+	mapped := NewOrderedMap[K, V]()
+
+	o.Range(func(k K, v V) bool {
+		mapped.set(k, mapper(v))
+		return true
+	})
+
+	return mapped
+}
+
+// Range calls fn for each element in the insertion order until fn returns false.
+// The read lock is held during the whole iteration, so fn must not modify OrderedMap[K, V].
+func (o OrderedMap[K, V]) Range(fn func(K, V) bool) {
+	o.mutex.RLock()
+	defer o.mutex.RUnlock()
+
+	for e := o.order.Front(); e != nil; e = e.Next() {
+		entry := e.Value.(*Entry[K, V])
+		if !fn(entry.Key, entry.Value) {
+			return
+		}
+	}
+}
+
+// Keys return the keys in the insertion order.
+func (o OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, o.Len())
+
+	o.Range(func(k K, _ V) bool {
+		keys = append(keys, k)
+		return true
+	})
+
+	return keys
+}
+
+// Values return the values in the insertion order of their keys.
+func (o OrderedMap[K, V]) Values() []V {
+	values := make([]V, 0, o.Len())
+
+	o.Range(func(_ K, v V) bool {
+		values = append(values, v)
+		return true
+	})
+
+	return values
+}
+
+// Entries return the elements in the insertion order.
+func (o OrderedMap[K, V]) Entries() []Entry[K, V] {
+	entries := make([]Entry[K, V], 0, o.Len())
+
+	o.Range(func(k K, v V) bool {
+		entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		return true
+	})
+
+	return entries
+}
+
+// MarshalJSON encodes OrderedMap[K, V] as the JSON object with the keys in the insertion order.
+// The keys must be the strings, the integers or implement encoding.TextMarshaler.
+func (o OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	var (
+		buf bytes.Buffer
+		err error
+	)
+
+	buf.WriteByte('{')
+
+	o.Range(func(k K, v V) bool {
+		var key, value []byte
+
+		if key, err = marshalKey(k); err != nil {
+			return false
+		}
+
+		if value, err = json.Marshal(v); err != nil {
+			err = fmt.Errorf("marshal value of %s: %w", key, err)
+			return false
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+
+		return true
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON decodes the JSON object into OrderedMap[K, V] in the order of its keys.
+// The decoded elements are added to the existing ones.
+func (o *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	t, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if delim, ok := t.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("gomap: OrderedMap expects JSON object, got %v", t)
+	}
+
+	if o.mutex == nil {
+		*o = NewOrderedMap[K, V]()
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		k, err := unmarshalKey[K](t.(string))
+		if err != nil {
+			return err
+		}
+
+		var v V
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("unmarshal value of %q: %w", t, err)
+		}
+
+		o.set(k, v)
+	}
+
+	_, err = dec.Token()
+
+	return err
+}
+
+// set adds or replaces the element. The lock must be held.
+func (o OrderedMap[K, V]) set(k K, v V) {
+	if e, exists := o.elements[k]; exists {
+		e.Value.(*Entry[K, V]).Value = v
+		return
+	}
+
+	o.elements[k] = o.order.PushBack(&Entry[K, V]{Key: k, Value: v})
+}
+
+func marshalKey[K comparable](k K) ([]byte, error) {
+	if tm, ok := any(k).(encoding.TextMarshaler); ok {
+		text, err := tm.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+
+		return json.Marshal(string(text))
+	}
+
+	key, err := json.Marshal(k)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case len(key) > 0 && key[0] == '"':
+		return key, nil
+	case len(key) > 0 && (key[0] == '-' || key[0] >= '0' && key[0] <= '9'):
+		return []byte(strconv.Quote(string(key))), nil
+	default:
+		return nil, fmt.Errorf("gomap: unsupported JSON object key %s of type %s", key, typeName[K]())
+	}
+}
+
+func unmarshalKey[K comparable](s string) (K, error) {
+	var k K
+
+	if tu, ok := any(&k).(encoding.TextUnmarshaler); ok {
+		return k, tu.UnmarshalText([]byte(s))
+	}
+
+	if err := json.Unmarshal([]byte(strconv.Quote(s)), &k); err == nil {
+		return k, nil
+	}
+
+	if err := json.Unmarshal([]byte(s), &k); err != nil {
+		return k, fmt.Errorf("gomap: unsupported JSON object key %q for type %s: %w", s, typeName[K](), err)
+	}
+
+	return k, nil
+}
@@ -0,0 +1,68 @@
+package gomap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestOrderedMapKeepsInsertionOrder(t *testing.T) {
+	o := gomap.NewOrderedMap[string, int]()
+	o.Add("c", 3).Add("a", 1).Add("b", 2)
+
+	assert.Equal(t, []string{"c", "a", "b"}, o.Keys())
+	assert.Equal(t, []int{3, 1, 2}, o.Values())
+
+	o.Add("c", 30)
+	assert.Equal(t, []string{"c", "a", "b"}, o.Keys(), "replacing the value keeps the position")
+
+	v, ok := o.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 30, v)
+
+	assert.True(t, o.Delete("a"))
+	assert.False(t, o.Delete("a"))
+	assert.False(t, o.Exists("a"))
+
+	o.Add("a", 1)
+	assert.Equal(t, []gomap.Entry[string, int]{{Key: "c", Value: 30}, {Key: "b", Value: 2}, {Key: "a", Value: 1}}, o.Entries())
+	assert.Equal(t, 3, o.Len())
+}
+
+func TestOrderedMapFilterAndEach(t *testing.T) {
+	o := gomap.OrderedFrom(
+		gomap.Entry[string, int]{Key: "z", Value: 1},
+		gomap.Entry[string, int]{Key: "y", Value: 2},
+		gomap.Entry[string, int]{Key: "x", Value: 3},
+	)
+
+	odd := o.Filter(func(_ string, v int) bool { return v%2 == 1 })
+	assert.Equal(t, []string{"z", "x"}, odd.Keys())
+
+	doubled := o.Each(func(v int) int { return v * 2 })
+	assert.Equal(t, []int{2, 4, 6}, doubled.Values())
+	assert.Equal(t, []int{1, 2, 3}, o.Values())
+}
+
+func TestOrderedMapJSON(t *testing.T) {
+	o := gomap.NewOrderedMap[string, int]()
+	o.Add("b", 2).Add("a", 1)
+
+	data, err := json.Marshal(o)
+	require.NoError(t, err)
+	assert.Equal(t, `{"b":2,"a":1}`, string(data))
+
+	var decoded gomap.OrderedMap[string, int]
+	require.NoError(t, json.Unmarshal([]byte(`{"z":1,"a":2,"m":3}`), &decoded))
+	assert.Equal(t, []string{"z", "a", "m"}, decoded.Keys())
+
+	var ints gomap.OrderedMap[int, string]
+	require.NoError(t, json.Unmarshal([]byte(`{"2":"b","1":"a"}`), &ints))
+	assert.Equal(t, []int{2, 1}, ints.Keys())
+
+	assert.Error(t, json.Unmarshal([]byte(`[1]`), &decoded))
+}
@@ -0,0 +1,124 @@
+package gomap
+
+import "fmt"
+
+// QuotaPolicy decides what happens with the new key of the namespace which is full.
+type QuotaPolicy int
+
+const (
+	// QuotaReject rejects the new key with ErrQuotaExceeded.
+	QuotaReject QuotaPolicy = iota
+	// QuotaEvict deletes an arbitrary key of the same namespace to make room for the new one.
+	QuotaEvict
+)
+
+// Quota limits the number of keys per namespace, e.g. per tenant prefix of the key.
+type Quota[K comparable] struct {
+	// Namespace return the namespace of the key.
+	Namespace func(K) string
+	// Limits contains the maximal number of keys per namespace.
+	Limits map[string]int
+	// Default is the limit of the namespaces missing in Limits. Zero means no limit.
+	Default int
+	// Policy is applied to the new keys of the full namespace.
+	Policy QuotaPolicy
+}
+
+// WithQuota limits the number of keys per namespace of Map, so one tenant cannot consume all of its capacity.
+// Only the keys added through Map methods are accounted, the writes to the builtin map returned by MapUnsafe bypass the quota.
+func WithQuota[K comparable, V any](q Quota[K]) Option[K, V] {
+	return func(c *config[K, V]) {
+		c.quota = &quota[K, V]{Quota: q}
+		c.quota.reset()
+	}
+}
+
+// quota is guarded by the write lock of its Map.
+type quota[K comparable, V any] struct {
+	Quota[K]
+
+	counts map[string]int
+	// keys indexes the keys by namespace for QuotaEvict, so the victim is found without scanning the Map.
+	keys map[string]map[K]struct{}
+}
+
+func (q *quota[K, V]) reset() {
+	q.counts = make(map[string]int)
+	if q.Policy == QuotaEvict {
+		q.keys = make(map[string]map[K]struct{})
+	}
+}
+
+func (q *quota[K, V]) limit(namespace string) int {
+	if limit, exists := q.Limits[namespace]; exists {
+		return limit
+	}
+
+	return q.Default
+}
+
+// admit checks the new key against the quota and return the key to evict to make room for it, if any.
+func (q *quota[K, V]) admit(elements map[K]V, k K) (*K, error) {
+	namespace := q.Namespace(k)
+
+	limit := q.limit(namespace)
+	if limit <= 0 || q.counts[namespace] < limit {
+		return nil, nil
+	}
+
+	if q.Policy == QuotaEvict {
+		for victim := range q.keys[namespace] {
+			// the keys deleted from the builtin map returned by MapUnsafe are still indexed.
+			if _, exists := elements[victim]; exists {
+				return &victim, nil
+			}
+
+			delete(q.keys[namespace], victim)
+		}
+	}
+
+	return nil, fmt.Errorf("namespace %q holds %d keys: %w", namespace, q.counts[namespace], ErrQuotaExceeded)
+}
+
+func (q *quota[K, V]) inserted(k K) {
+	namespace := q.Namespace(k)
+	q.counts[namespace]++
+
+	if q.keys != nil {
+		if q.keys[namespace] == nil {
+			q.keys[namespace] = make(map[K]struct{})
+		}
+
+		q.keys[namespace][k] = struct{}{}
+	}
+}
+
+func (q *quota[K, V]) removed(k K) {
+	namespace := q.Namespace(k)
+
+	if q.counts[namespace]--; q.counts[namespace] <= 0 {
+		delete(q.counts, namespace)
+	}
+
+	if q.keys != nil {
+		if delete(q.keys[namespace], k); len(q.keys[namespace]) == 0 {
+			delete(q.keys, namespace)
+		}
+	}
+}
+
+// fits checks whether the elements fit the quota on their own.
+func (q *quota[K, V]) fits(elements map[K]V) error {
+	counts := make(map[string]int)
+	for k := range elements {
+		counts[q.Namespace(k)]++
+	}
+
+	for namespace, count := range counts {
+		if limit := q.limit(namespace); limit > 0 && count > limit {
+			return fmt.Errorf("namespace %q would hold %d keys: %w", namespace, count, ErrQuotaExceeded)
+		}
+	}
+
+	return nil
+}
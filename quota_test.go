@@ -0,0 +1,55 @@
+package gomap_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func tenant(k string) string {
+	return strings.SplitN(k, ":", 2)[0]
+}
+
+func TestQuotaReject(t *testing.T) {
+	m := gomap.New(gomap.WithQuota[string, int](gomap.Quota[string]{
+		Namespace: tenant,
+		Default:   2,
+	}))
+
+	require.NoError(t, m.TryAdd("a:1", 1))
+	require.NoError(t, m.TryAdd("a:2", 2))
+	require.ErrorIs(t, m.TryAdd("a:3", 3), gomap.ErrQuotaExceeded)
+	require.NoError(t, m.TryAdd("b:1", 1))
+
+	m.Delete("a:1")
+	assert.NoError(t, m.TryAdd("a:3", 3))
+}
+
+func TestQuotaEvict(t *testing.T) {
+	m := gomap.New(gomap.WithQuota[string, int](gomap.Quota[string]{
+		Namespace: tenant,
+		Limits:    map[string]int{"a": 2},
+		Policy:    gomap.QuotaEvict,
+	}))
+
+	m.Add("a:1", 1).Add("a:2", 2).Add("b:1", 1).Add("a:3", 3)
+
+	assert.Equal(t, 3, m.Len())
+	assert.True(t, m.Exists("a:3"))
+	assert.True(t, m.Exists("b:1"))
+	assert.False(t, m.Exists("a:1") && m.Exists("a:2"))
+
+	for i := 0; i < 100; i++ {
+		m.Add("a:x"+strings.Repeat("x", i), i)
+	}
+
+	assert.Equal(t, 3, m.Len())
+
+	m.Clear()
+	m.Add("a:1", 1).Add("a:2", 2).Add("a:3", 3)
+	assert.Equal(t, 2, m.Len())
+}
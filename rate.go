@@ -0,0 +1,114 @@
+package gomap
+
+import "time"
+
+// MutationRate configures the tracking of the Map mutations per interval.
+type MutationRate[K comparable] struct {
+	// Interval is the length of the counting window, one second by default.
+	Interval time.Duration
+	// Threshold is the number of mutations per Interval which triggers OnExceed.
+	Threshold int
+	// Group optionally splits the counting by the group of the key, e.g. the key prefix.
+	// All the keys belong to the single "" group by default.
+	Group func(K) string
+	// OnExceed is called in its own goroutine once per Interval and group, when the number of mutations reaches Threshold.
+	OnExceed func(group string, count int)
+	// Clock is SystemClock by default.
+	Clock Clock
+}
+
+// WithMutationRate tracks the mutations of Map per interval, so the runaway writers and delete storms can be detected.
+// The counts of the last completed interval are available with Map.MutationRates.
+func WithMutationRate[K comparable, V any](rate MutationRate[K]) Option[K, V] {
+	return func(c *config[K, V]) {
+		if rate.Interval <= 0 {
+			rate.Interval = time.Second
+		}
+
+		if rate.Clock == nil {
+			rate.Clock = SystemClock
+		}
+
+		c.rate = &rateMonitor[K]{
+			MutationRate: rate,
+			current:      make(map[string]int),
+			previous:     make(map[string]int),
+			exceeded:     make(map[string]struct{}),
+		}
+	}
+}
+
+// MutationRates return the number of mutations per group in the last completed interval.
+// It's nil if the Map is not configured WithMutationRate.
+func (m Map[K, V]) MutationRates() map[string]int {
+	if m.config == nil || m.config.rate == nil {
+		return nil
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	r := m.config.rate
+	r.rotate()
+
+	rates := make(map[string]int, len(r.previous))
+	for group, count := range r.previous {
+		rates[group] = count
+	}
+
+	return rates
+}
+
+// rateMonitor is guarded by the write lock of its Map.
+type rateMonitor[K comparable] struct {
+	MutationRate[K]
+
+	windowStart time.Time
+	current     map[string]int
+	previous    map[string]int
+	exceeded    map[string]struct{}
+}
+
+func (r *rateMonitor[K]) record(k K) {
+	r.rotate()
+
+	var group string
+	if r.Group != nil {
+		group = r.Group(k)
+	}
+
+	r.current[group]++
+
+	if r.OnExceed == nil || r.Threshold <= 0 || r.current[group] < r.Threshold {
+		return
+	}
+
+	if _, fired := r.exceeded[group]; !fired {
+		r.exceeded[group] = struct{}{}
+		go r.OnExceed(group, r.current[group])
+	}
+}
+
+func (r *rateMonitor[K]) rotate() {
+	now := r.Clock.Now()
+
+	if r.windowStart.IsZero() {
+		r.windowStart = now
+		return
+	}
+
+	elapsed := now.Sub(r.windowStart)
+	if elapsed < r.Interval {
+		return
+	}
+
+	if elapsed < 2*r.Interval {
+		r.previous = r.current
+	} else {
+		r.previous = make(map[string]int)
+	}
+
+	r.current = make(map[string]int)
+	r.exceeded = make(map[string]struct{})
+	r.windowStart = now.Add(-elapsed % r.Interval)
+}
@@ -0,0 +1,350 @@
+package gomap
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Registry manages the named Maps, ShardedMaps and LRUs of the service, so they can be enumerated, inspected
+// and cleared in one place.
+type Registry struct {
+	mutex *sync.RWMutex
+	maps  map[string]registered
+}
+
+type registered struct {
+	value      any
+	typ        string
+	stats      func() RegistryStats
+	clear      func()
+	lookup     func(key string) (any, bool)
+	delete     func(key string) bool
+	exportTo   func(w io.Writer) error
+	importFrom func(r io.Reader) error
+}
+
+// RegistryStats describes the Map managed by Registry.
+type RegistryStats struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Len  int    `json:"len"`
+	// Capacity is the maximal number of elements of the LRU, zero for the unbounded maps.
+	Capacity int `json:"capacity,omitempty"`
+	// HitRatio is the share of the reads which found the element, reported for the Maps created WithMeta once they are read.
+	HitRatio *float64 `json:"hit_ratio,omitempty"`
+	// Oldest is the creation time of the oldest element, reported for the non-empty Maps created WithMeta.
+	Oldest *time.Time `json:"oldest,omitempty"`
+	// Shards is the number of elements of each shard of the ShardedMap.
+	Shards []int `json:"shards,omitempty"`
+}
+
+// NewRegistry creates the empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		mutex: &sync.RWMutex{},
+		maps:  make(map[string]registered),
+	}
+}
+
+// GetOrCreate return the Map[K, V] registered under the name, creating the empty one with the options if it does not exist.
+// The options of the existing Map are not changed. ErrTypeMismatch is returned if the name is already taken by the Map of another type.
+func GetOrCreate[K comparable, V any](r *Registry, name string, opts ...Option[K, V]) (Map[K, V], error) {
+	return getOrCreate(r, name, fmt.Sprintf("Map[%s, %s]", typeName[K](), typeName[V]()), func() (Map[K, V], registered) {
+		m := newMap(make(map[K]V), opts...)
+
+		return m, registered{
+			stats: func() RegistryStats { return statsOf(m) },
+			clear: m.Clear,
+			lookup: func(key string) (any, bool) {
+				if k, found := m.findKey(key); found {
+					return m.Get(k)
+				}
+
+				return nil, false
+			},
+			delete: func(key string) bool {
+				if k, found := m.findKey(key); found {
+					return m.Delete(k)
+				}
+
+				return false
+			},
+			exportTo:   func(w io.Writer) error { return m.Export(w) },
+			importFrom: func(r io.Reader) error { return m.Import(r) },
+		}
+	})
+}
+
+// GetOrCreateSharded return the ShardedMap[K, V] registered under the name, creating the empty one if it does not exist,
+// see NewShardedMap. Its stats include the number of elements of each shard.
+func GetOrCreateSharded[K comparable, V any](r *Registry, name string, shards int, opts ...Option[K, V]) (ShardedMap[K, V], error) {
+	return getOrCreate(r, name, fmt.Sprintf("ShardedMap[%s, %s]", typeName[K](), typeName[V]()), func() (ShardedMap[K, V], registered) {
+		s := NewShardedMap(shards, opts...)
+
+		return s, registered{
+			stats: func() RegistryStats {
+				stats := statsOf(s.shards...)
+				for _, shard := range s.shards {
+					stats.Shards = append(stats.Shards, shard.Len())
+				}
+
+				return stats
+			},
+			clear: s.Clear,
+			lookup: func(key string) (any, bool) {
+				for _, shard := range s.shards {
+					if k, found := shard.findKey(key); found {
+						return s.Get(k)
+					}
+				}
+
+				return nil, false
+			},
+			delete: func(key string) bool {
+				for _, shard := range s.shards {
+					if k, found := shard.findKey(key); found {
+						return s.Delete(k)
+					}
+				}
+
+				return false
+			},
+			exportTo: func(w io.Writer) error { return newMap(s.MapCopy()).Export(w) },
+			importFrom: func(r io.Reader) error {
+				imported := newMap(make(map[K]V))
+				if err := imported.Import(r); err != nil {
+					return err
+				}
+
+				for k, v := range imported.innerMap {
+					s.Add(k, v)
+				}
+
+				return nil
+			},
+		}
+	})
+}
+
+// GetOrCreateLRU return the LRU[K, V] registered under the name, creating the empty one of the capacity if it does not exist,
+// see NewLRU. Its stats include the capacity. The lookups by the Registry do not change the recency.
+func GetOrCreateLRU[K comparable, V any](r *Registry, name string, capacity int, opts ...LRUOption[K, V]) (LRU[K, V], error) {
+	return getOrCreate(r, name, fmt.Sprintf("LRU[%s, %s]", typeName[K](), typeName[V]()), func() (LRU[K, V], registered) {
+		l := NewLRU(capacity, opts...)
+
+		return l, registered{
+			stats: func() RegistryStats {
+				return RegistryStats{Len: l.Len(), Capacity: l.capacity}
+			},
+			clear: func() {
+				for _, k := range l.Keys() {
+					l.Delete(k)
+				}
+			},
+			lookup: func(key string) (any, bool) {
+				if k, found := l.Map().findKey(key); found {
+					return l.Peek(k)
+				}
+
+				return nil, false
+			},
+			delete: func(key string) bool {
+				if k, found := l.Map().findKey(key); found {
+					return l.Delete(k)
+				}
+
+				return false
+			},
+			exportTo: func(w io.Writer) error { return l.Map().Export(w) },
+			importFrom: func(r io.Reader) error {
+				imported := newMap(make(map[K]V))
+				if err := imported.Import(r); err != nil {
+					return err
+				}
+
+				for k, v := range imported.innerMap {
+					l.Add(k, v)
+				}
+
+				return nil
+			},
+		}
+	})
+}
+
+// getOrCreate return the value registered under the name, registering the one created by create if it does not exist.
+func getOrCreate[T any](r *Registry, name, typ string, create func() (T, registered)) (T, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if reg, exists := r.maps[name]; exists {
+		value, ok := reg.value.(T)
+		if !ok {
+			return value, fmt.Errorf("registry %q holds %s: %w", name, reg.typ, ErrTypeMismatch)
+		}
+
+		return value, nil
+	}
+
+	value, reg := create()
+	reg.value, reg.typ = value, typ
+	r.maps[name] = reg
+
+	return value, nil
+}
+
+// statsOf sums the stats of the Maps, the hit ratio and the oldest element are reported if they are created WithMeta.
+func statsOf[K comparable, V any](maps ...Map[K, V]) RegistryStats {
+	var (
+		stats        RegistryStats
+		hits, misses int64
+	)
+
+	for _, m := range maps {
+		stats.Len += m.Len()
+
+		h, mi, oldest, ok := m.metaStats()
+		if !ok {
+			continue
+		}
+
+		hits, misses = hits+h, misses+mi
+
+		if !oldest.IsZero() && (stats.Oldest == nil || oldest.Before(*stats.Oldest)) {
+			stats.Oldest = &oldest
+		}
+	}
+
+	if hits+misses > 0 {
+		ratio := float64(hits) / float64(hits+misses)
+		stats.HitRatio = &ratio
+	}
+
+	return stats
+}
+
+// Names return the sorted names of the registered Maps.
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.maps))
+	for name := range r.maps {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Stats return the RegistryStats of each registered Map sorted by name.
+func (r *Registry) Stats() []RegistryStats {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	stats := make([]RegistryStats, 0, len(r.maps))
+	for name, reg := range r.maps {
+		stat := reg.stats()
+		stat.Name, stat.Type = name, reg.typ
+		stats = append(stats, stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
+
+// ClearAll removes all the elements from each registered Map.
+func (r *Registry) ClearAll() {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, reg := range r.maps {
+		reg.clear()
+	}
+}
+
+// Close clears each registered Map and removes them from the Registry.
+func (r *Registry) Close() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for name, reg := range r.maps {
+		reg.clear()
+		delete(r.maps, name)
+	}
+}
+
+// Lookup return the value stored by the key in the Map registered under the name.
+// The key is matched against the keys of the Map formatted with fmt's %v verb.
+func (r *Registry) Lookup(name, key string) (any, bool, error) {
+	reg, err := r.get(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	v, exists := reg.lookup(key)
+
+	return v, exists, nil
+}
+
+// DeleteKey deletes the element by the key from the Map registered under the name.
+// The key is matched against the keys of the Map formatted with fmt's %v verb.
+func (r *Registry) DeleteKey(name, key string) (bool, error) {
+	reg, err := r.get(name)
+	if err != nil {
+		return false, err
+	}
+
+	return reg.delete(key), nil
+}
+
+// Clear removes all the elements from the Map registered under the name.
+func (r *Registry) Clear(name string) error {
+	reg, err := r.get(name)
+	if err != nil {
+		return err
+	}
+
+	reg.clear()
+
+	return nil
+}
+
+func (r *Registry) get(name string) (registered, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	reg, exists := r.maps[name]
+	if !exists {
+		return registered{}, fmt.Errorf("registry %q: %w", name, ErrNotRegistered)
+	}
+
+	return reg, nil
+}
+
+// Export writes the elements of the Map registered under the name to w in the format of Map.Export.
+func (r *Registry) Export(name string, w io.Writer) error {
+	reg, err := r.get(name)
+	if err != nil {
+		return err
+	}
+
+	return reg.exportTo(w)
+}
+
+// Import adds the elements read from r in the format of Map.Export to the Map registered under the name.
+func (r *Registry) Import(name string, rd io.Reader) error {
+	reg, err := r.get(name)
+	if err != nil {
+		return err
+	}
+
+	return reg.importFrom(rd)
+}
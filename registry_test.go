@@ -0,0 +1,96 @@
+package gomap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestRegistryStats(t *testing.T) {
+	r := gomap.NewRegistry()
+	clock := gomaptest.NewFakeClock(time.Unix(100, 0))
+
+	users, err := gomap.GetOrCreate(r, "users", gomap.WithMeta[string, int](clock))
+	require.NoError(t, err)
+
+	users.Add("a", 1)
+	clock.Advance(time.Second)
+	users.Add("b", 2)
+
+	users.Get("a")
+	users.Get("b")
+	users.Get("c")
+	users.Get("d")
+
+	sessions, err := gomap.GetOrCreateSharded[int, int](r, "sessions", 4)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		sessions.Add(i, i)
+	}
+
+	lru, err := gomap.GetOrCreateLRU[string, int](r, "lru", 10)
+	require.NoError(t, err)
+	lru.Add("a", 1)
+
+	stats := r.Stats()
+	require.Len(t, stats, 3)
+
+	assert.Equal(t, "lru", stats[0].Name)
+	assert.Equal(t, "LRU[string, int]", stats[0].Type)
+	assert.Equal(t, 1, stats[0].Len)
+	assert.Equal(t, 10, stats[0].Capacity)
+
+	assert.Equal(t, "sessions", stats[1].Name)
+	assert.Equal(t, 100, stats[1].Len)
+	require.Len(t, stats[1].Shards, 4)
+
+	sum := 0
+	for _, n := range stats[1].Shards {
+		sum += n
+	}
+	assert.Equal(t, 100, sum)
+	assert.Nil(t, stats[1].HitRatio, "the map without meta has no hit ratio")
+
+	assert.Equal(t, "users", stats[2].Name)
+	assert.Equal(t, "Map[string, int]", stats[2].Type)
+	require.NotNil(t, stats[2].HitRatio)
+	assert.Equal(t, 0.5, *stats[2].HitRatio)
+	require.NotNil(t, stats[2].Oldest)
+	assert.True(t, time.Unix(100, 0).Equal(*stats[2].Oldest))
+}
+
+func TestRegistryTypeMismatch(t *testing.T) {
+	r := gomap.NewRegistry()
+
+	_, err := gomap.GetOrCreate[string, int](r, "m")
+	require.NoError(t, err)
+
+	_, err = gomap.GetOrCreate[string, string](r, "m")
+	assert.ErrorIs(t, err, gomap.ErrTypeMismatch)
+
+	_, err = gomap.GetOrCreateLRU[string, int](r, "m", 1)
+	assert.ErrorIs(t, err, gomap.ErrTypeMismatch)
+}
+
+func TestRegistryLookupSharded(t *testing.T) {
+	r := gomap.NewRegistry()
+
+	s, err := gomap.GetOrCreateSharded[int, string](r, "s", 4)
+	require.NoError(t, err)
+	s.Add(42, "answer")
+
+	v, found, err := r.Lookup("s", "42")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "answer", v)
+
+	deleted, err := r.DeleteKey("s", "42")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	assert.Equal(t, 0, s.Len())
+}
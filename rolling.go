@@ -0,0 +1,151 @@
+package gomap
+
+import "time"
+
+type rollingBucket struct {
+	epoch int64
+	count int64
+	sum   float64
+}
+
+type rollingOptions struct {
+	clock Clock
+}
+
+// RollingOption configures the RollingWindow.
+type RollingOption func(*rollingOptions)
+
+// WithRollingClock sets the Clock of the RollingWindow, SystemClock by default.
+func WithRollingClock(clock Clock) RollingOption {
+	return func(o *rollingOptions) {
+		o.clock = clock
+	}
+}
+
+// RollingWindow is a concurrency safe per key aggregator of the count and the sum of values over the last window.
+// The window is split into the fixed number of buckets, so the updates are O(1) and no events are stored.
+type RollingWindow[K comparable] struct {
+	keys        Map[K, []rollingBucket]
+	granularity time.Duration
+	buckets     int
+	clock       Clock
+}
+
+// NewRollingWindow creates the empty RollingWindow[K] over the window split into the buckets.
+// More buckets make the window boundary more precise at the cost of memory per key.
+func NewRollingWindow[K comparable](window time.Duration, buckets int, opts ...RollingOption) RollingWindow[K] {
+	o := rollingOptions{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if buckets < 1 {
+		buckets = 1
+	}
+
+	granularity := window / time.Duration(buckets)
+	if granularity <= 0 {
+		granularity = 1
+	}
+
+	return RollingWindow[K]{
+		keys:        From(map[K][]rollingBucket{}),
+		granularity: granularity,
+		buckets:     buckets,
+		clock:       o.clock,
+	}
+}
+
+// Add records the value for the key, e.g. 1 per request for the request rate.
+func (r RollingWindow[K]) Add(k K, value float64) {
+	epoch := r.epoch()
+	m := r.keys
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	ring, exists := m.innerMap[k]
+	if !exists {
+		ring = make([]rollingBucket, r.buckets)
+		_ = m.store(k, ring)
+	}
+
+	bucket := &ring[epoch%int64(r.buckets)]
+	if bucket.epoch != epoch {
+		*bucket = rollingBucket{epoch: epoch}
+	}
+
+	bucket.count++
+	bucket.sum += value
+}
+
+// Count return the number of values recorded for the key within the window.
+func (r RollingWindow[K]) Count(k K) int64 {
+	count, _ := r.Stats(k)
+
+	return count
+}
+
+// Sum return the sum of values recorded for the key within the window.
+func (r RollingWindow[K]) Sum(k K) float64 {
+	_, sum := r.Stats(k)
+
+	return sum
+}
+
+// Stats return both the count and the sum of values recorded for the key within the window.
+func (r RollingWindow[K]) Stats(k K) (int64, float64) {
+	epoch := r.epoch()
+	m := r.keys
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var (
+		count int64
+		sum   float64
+	)
+
+	for _, bucket := range m.innerMap[k] {
+		if r.live(bucket, epoch) {
+			count += bucket.count
+			sum += bucket.sum
+		}
+	}
+
+	return count, sum
+}
+
+// Prune deletes the keys with no values within the window and return the number of deleted keys.
+func (r RollingWindow[K]) Prune() int {
+	epoch := r.epoch()
+	m := r.keys
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var pruned int
+	for k, ring := range m.innerMap {
+		live := false
+		for _, bucket := range ring {
+			if live = r.live(bucket, epoch); live {
+				break
+			}
+		}
+
+		if !live {
+			m.remove(k)
+			pruned++
+		}
+	}
+
+	return pruned
+}
+
+func (r RollingWindow[K]) epoch() int64 {
+	return r.clock.Now().UnixNano() / int64(r.granularity)
+}
+
+func (r RollingWindow[K]) live(bucket rollingBucket, epoch int64) bool {
+	return bucket.count > 0 && epoch-bucket.epoch < int64(r.buckets)
+}
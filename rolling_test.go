@@ -0,0 +1,72 @@
+package gomap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestRollingWindow(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	r := gomap.NewRollingWindow[string](time.Minute, 6, gomap.WithRollingClock(clock))
+
+	r.Add("a", 1)
+	r.Add("a", 2)
+	clock.Advance(30 * time.Second)
+	r.Add("a", 3)
+	r.Add("b", 10)
+
+	count, sum := r.Stats("a")
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, 6.0, sum)
+	assert.Equal(t, int64(1), r.Count("b"))
+	assert.Equal(t, 10.0, r.Sum("b"))
+	assert.Zero(t, r.Count("missing"))
+}
+
+func TestRollingWindowSlides(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	r := gomap.NewRollingWindow[string](time.Minute, 6, gomap.WithRollingClock(clock))
+
+	r.Add("a", 1)
+	clock.Advance(30 * time.Second)
+	r.Add("a", 2)
+
+	clock.Advance(40 * time.Second)
+	assert.Equal(t, int64(1), r.Count("a"))
+	assert.Equal(t, 2.0, r.Sum("a"))
+
+	clock.Advance(time.Minute)
+	assert.Zero(t, r.Count("a"))
+}
+
+func TestRollingWindowReusesStaleBuckets(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	r := gomap.NewRollingWindow[string](time.Minute, 6, gomap.WithRollingClock(clock))
+
+	r.Add("a", 5)
+	clock.Advance(time.Minute)
+	r.Add("a", 1)
+
+	count, sum := r.Stats("a")
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, 1.0, sum)
+}
+
+func TestRollingWindowPrune(t *testing.T) {
+	clock := gomaptest.NewFakeClock(time.Unix(0, 0))
+	r := gomap.NewRollingWindow[string](time.Minute, 6, gomap.WithRollingClock(clock))
+
+	r.Add("a", 1)
+	clock.Advance(50 * time.Second)
+	r.Add("b", 1)
+	clock.Advance(20 * time.Second)
+
+	assert.Equal(t, 1, r.Prune())
+	assert.Equal(t, int64(1), r.Count("b"))
+	assert.Zero(t, r.Prune())
+}
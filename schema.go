@@ -0,0 +1,200 @@
+package gomap
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FieldKind is the type a schema field is coerced to.
+type FieldKind int
+
+const (
+	// KindAny accepts any value as is.
+	KindAny FieldKind = iota
+	// KindString coerces to string.
+	KindString
+	// KindInt coerces to int.
+	KindInt
+	// KindFloat coerces to float64.
+	KindFloat
+	// KindBool coerces to bool.
+	KindBool
+	// KindDuration coerces to time.Duration, the strings are parsed by time.ParseDuration.
+	KindDuration
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case KindString:
+		return "string"
+	case KindInt:
+		return "int"
+	case KindFloat:
+		return "float"
+	case KindBool:
+		return "bool"
+	case KindDuration:
+		return "duration"
+	default:
+		return "any"
+	}
+}
+
+// Field describes one field of the Schema.
+type Field struct {
+	Kind     FieldKind
+	Required bool
+	// Default is set when the field is missing and not Required, nil means no default.
+	Default any
+}
+
+// Schema describes the fields of Map[string, any], e.g. the loaded configuration.
+type Schema map[string]Field
+
+// FieldError is the violation of one field.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e FieldError) Unwrap() error {
+	return e.Err
+}
+
+// SchemaError contains all the violations found by Schema.Apply, sorted by field.
+type SchemaError struct {
+	Fields []FieldError
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		msgs = append(msgs, f.Error())
+	}
+
+	return "gomap: schema violations: " + strings.Join(msgs, "; ")
+}
+
+// Apply validates the Map[string, any] against the Schema, coerces the values to the field kinds and sets the defaults.
+// If any field is invalid, the *SchemaError is returned and the Map is left unchanged.
+// The fields missing in the Schema are kept as is.
+func (s Schema) Apply(m Map[string, any]) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	coerced := make(map[string]any, len(s))
+	var violations []FieldError
+
+	for name, field := range s {
+		v, exists := m.innerMap[name]
+
+		if !exists {
+			switch {
+			case field.Required:
+				violations = append(violations, FieldError{Field: name, Err: ErrFieldRequired})
+			case field.Default != nil:
+				coerced[name] = field.Default
+			}
+
+			continue
+		}
+
+		c, err := coerce(v, field.Kind)
+		if err != nil {
+			violations = append(violations, FieldError{Field: name, Err: err})
+			continue
+		}
+
+		coerced[name] = c
+	}
+
+	if len(violations) > 0 {
+		sort.Slice(violations, func(i, j int) bool {
+			return violations[i].Field < violations[j].Field
+		})
+
+		return &SchemaError{Fields: violations}
+	}
+
+	for name, v := range coerced {
+		_ = m.store(name, v)
+	}
+
+	return nil
+}
+
+func coerce(v any, kind FieldKind) (any, error) {
+	fail := func() (any, error) {
+		return nil, fmt.Errorf("%w: %T %v to %s", ErrFieldKind, v, v, kind)
+	}
+
+	switch kind {
+	case KindString:
+		switch t := v.(type) {
+		case string:
+			return t, nil
+		case fmt.Stringer:
+			return t.String(), nil
+		case int, int64, float64, bool:
+			return fmt.Sprint(t), nil
+		}
+	case KindInt:
+		switch t := v.(type) {
+		case int:
+			return t, nil
+		case int64:
+			return int(t), nil
+		case float64:
+			if t == math.Trunc(t) {
+				return int(t), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(strings.TrimSpace(t)); err == nil {
+				return i, nil
+			}
+		}
+	case KindFloat:
+		switch t := v.(type) {
+		case float64:
+			return t, nil
+		case int:
+			return float64(t), nil
+		case int64:
+			return float64(t), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(t), 64); err == nil {
+				return f, nil
+			}
+		}
+	case KindBool:
+		switch t := v.(type) {
+		case bool:
+			return t, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(t)); err == nil {
+				return b, nil
+			}
+		}
+	case KindDuration:
+		switch t := v.(type) {
+		case time.Duration:
+			return t, nil
+		case string:
+			if d, err := time.ParseDuration(strings.TrimSpace(t)); err == nil {
+				return d, nil
+			}
+		}
+	default:
+		return v, nil
+	}
+
+	return fail()
+}
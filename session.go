@@ -0,0 +1,173 @@
+package gomap
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+type session[V any] struct {
+	Value     V         `json:"value"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type sessionOptions struct {
+	clock Clock
+}
+
+// SessionOption configures the SessionMap.
+type SessionOption func(*sessionOptions)
+
+// WithSessionClock sets the Clock used to expire the sessions, SystemClock by default.
+func WithSessionClock(clock Clock) SessionOption {
+	return func(o *sessionOptions) {
+		o.clock = clock
+	}
+}
+
+// SessionMap is a concurrency safe session storage keyed by the random session ids.
+// The session expires when it was not accessed for the ttl, each access extends its lifetime by the ttl.
+type SessionMap[V any] struct {
+	sessions Map[string, session[V]]
+	ttl      time.Duration
+	clock    Clock
+}
+
+// NewSessionMap creates the empty SessionMap[V] with the sliding ttl.
+func NewSessionMap[V any](ttl time.Duration, opts ...SessionOption) SessionMap[V] {
+	o := sessionOptions{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return SessionMap[V]{
+		sessions: From(map[string]session[V]{}),
+		ttl:      ttl,
+		clock:    o.clock,
+	}
+}
+
+// Create stores the value under the new cryptographically random session id and return the id.
+func (s SessionMap[V]) Create(v V) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate session id: %w", err)
+	}
+
+	id := base64.RawURLEncoding.EncodeToString(b)
+	s.sessions.Add(id, session[V]{Value: v, ExpiresAt: s.clock.Now().Add(s.ttl)})
+
+	return id, nil
+}
+
+// Get return the value of the session and extends its lifetime. The expired sessions are deleted and not returned.
+func (s SessionMap[V]) Get(id string) (V, bool) {
+	m := s.sessions
+	now := s.clock.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sess, exists := m.innerMap[id]
+	if !exists || !now.Before(sess.ExpiresAt) {
+		delete(m.innerMap, id)
+
+		var v V
+		return v, false
+	}
+
+	sess.ExpiresAt = now.Add(s.ttl)
+	m.innerMap[id] = sess
+
+	return sess.Value, true
+}
+
+// Set replaces the value of the existing session and extends its lifetime.
+func (s SessionMap[V]) Set(id string, v V) bool {
+	m := s.sessions
+	now := s.clock.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sess, exists := m.innerMap[id]
+	if !exists || !now.Before(sess.ExpiresAt) {
+		delete(m.innerMap, id)
+		return false
+	}
+
+	m.innerMap[id] = session[V]{Value: v, ExpiresAt: now.Add(s.ttl)}
+
+	return true
+}
+
+// Delete deletes the session.
+func (s SessionMap[V]) Delete(id string) bool {
+	return s.sessions.Delete(id)
+}
+
+// Len return the number of stored sessions, including expired but not yet deleted ones.
+func (s SessionMap[V]) Len() int {
+	return s.sessions.Len()
+}
+
+// DeleteExpired deletes the expired sessions and return the number of deleted ones.
+func (s SessionMap[V]) DeleteExpired() int {
+	m := s.sessions
+	now := s.clock.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	var deleted int
+	for id, sess := range m.innerMap {
+		if !now.Before(sess.ExpiresAt) {
+			delete(m.innerMap, id)
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+// Save writes the sessions with their expiration time to w as JSON.
+func (s SessionMap[V]) Save(w io.Writer) error {
+	m := s.sessions
+
+	m.mutex.RLock()
+	b, err := json.Marshal(m.innerMap)
+	m.mutex.RUnlock()
+
+	if err != nil {
+		return fmt.Errorf("save sessions: %w", err)
+	}
+
+	_, err = w.Write(b)
+
+	return err
+}
+
+// Load reads the sessions written by Save from r and adds the not expired ones to SessionMap[V].
+func (s SessionMap[V]) Load(r io.Reader) error {
+	var sessions map[string]session[V]
+	if err := json.NewDecoder(r).Decode(&sessions); err != nil {
+		return fmt.Errorf("load sessions: %w", err)
+	}
+
+	m := s.sessions
+	now := s.clock.Now()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for id, sess := range sessions {
+		if now.Before(sess.ExpiresAt) {
+			m.innerMap[id] = sess
+		}
+	}
+
+	return nil
+}
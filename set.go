@@ -0,0 +1,162 @@
+package gomap
+
+import "sync"
+
+// Set is a concurrency safe set of comparable values, backed by a builtin map.
+// The algebra operations return the new Set[T] and leave the operands unchanged.
+type Set[T comparable] struct {
+	mutex    *sync.RWMutex
+	innerSet map[T]struct{}
+}
+
+func newSet[T comparable](s map[T]struct{}) Set[T] {
+	return Set[T]{
+		mutex:    &sync.RWMutex{},
+		innerSet: s,
+	}
+}
+
+// NewSet creates the Set[T] from given values.
+func NewSet[T comparable](values ...T) Set[T] {
+	s := make(map[T]struct{}, len(values))
+
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+
+	return newSet(s)
+}
+
+// Add adds the values to Set[T].
+func (s Set[T]) Add(values ...T) Set[T] {
+	s.mutex.Lock()
+	for _, v := range values {
+		s.innerSet[v] = struct{}{}
+	}
+	s.mutex.Unlock()
+
+	return s
+}
+
+// Delete delete the value from Set[T].
+func (s Set[T]) Delete(v T) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.innerSet[v]; exists {
+		delete(s.innerSet, v)
+		return true
+	}
+
+	return false
+}
+
+// Has check if value exists in Set[T].
+func (s Set[T]) Has(v T) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	_, exists := s.innerSet[v]
+
+	return exists
+}
+
+// Len return the actual len of Set[T].
+func (s Set[T]) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return len(s.innerSet)
+}
+
+// Values return the values of Set[T] as slice in no particular order.
+func (s Set[T]) Values() []T {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	values := make([]T, 0, len(s.innerSet))
+	iterate(s.innerSet, func(v T, _ struct{}) {
+		values = append(values, v)
+	})
+
+	return values
+}
+
+// Union return the new Set[T] with the values existing in either Set[T].
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := s.snapshot()
+	for v := range other.snapshot() {
+		union[v] = struct{}{}
+	}
+
+	return newSet(union)
+}
+
+// Intersect return the new Set[T] with the values existing in both Set[T].
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	return s.filteredBy(other, true)
+}
+
+// Diff return the new Set[T] with the values of s which do not exist in other.
+func (s Set[T]) Diff(other Set[T]) Set[T] {
+	return s.filteredBy(other, false)
+}
+
+// SymmetricDiff return the new Set[T] with the values existing in exactly one of the Set[T].
+func (s Set[T]) SymmetricDiff(other Set[T]) Set[T] {
+	left, right := s.snapshot(), other.snapshot()
+
+	diff := make(map[T]struct{})
+	for v := range left {
+		if _, exists := right[v]; !exists {
+			diff[v] = struct{}{}
+		}
+	}
+
+	for v := range right {
+		if _, exists := left[v]; !exists {
+			diff[v] = struct{}{}
+		}
+	}
+
+	return newSet(diff)
+}
+
+// Map return the copy of Set[T] as Map[T, struct{}].
+func (s Set[T]) Map() Map[T, struct{}] {
+	return newMap(s.snapshot())
+}
+
+// SetFrom creates the Set[K] from the keys of Map[K, struct{}].
+func SetFrom[K comparable](m Map[K, struct{}]) Set[K] {
+	return newSet(m.MapCopy())
+}
+
+func (s Set[T]) filteredBy(other Set[T], present bool) Set[T] {
+	// other is copied first, so the same Set[T] on both sides does not take its lock twice.
+	right := other.snapshot()
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	filtered := make(map[T]struct{})
+	for v := range s.innerSet {
+		if _, exists := right[v]; exists == present {
+			filtered[v] = struct{}{}
+		}
+	}
+
+	return newSet(filtered)
+}
+
+func (s Set[T]) snapshot() map[T]struct{} {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	copied := make(map[T]struct{}, len(s.innerSet))
+	for v := range s.innerSet {
+		copied[v] = struct{}{}
+	}
+
+	return copied
+}
@@ -0,0 +1,221 @@
+package gomap
+
+import (
+	"fmt"
+	"hash/maphash"
+)
+
+// ShardedMap is a concurrency safe map partitioning the keys by hash across the shards, each being Map[K, V]
+// with its own lock, so the writers of the different keys rarely contend. The operations spanning all the keys,
+// like Len or Keys, lock the shards one by one, so they are not atomic across the shards.
+type ShardedMap[K comparable, V any] struct {
+	shards []Map[K, V]
+	mask   uint64
+	hash   func(K) uint64
+}
+
+// NewShardedMap creates the empty ShardedMap[K, V] with the number of shards rounded up to the power of two.
+// The options configure each shard, e.g. WithQuota limits every shard separately.
+func NewShardedMap[K comparable, V any](shards int, opts ...Option[K, V]) ShardedMap[K, V] {
+	return NewShardedMapFunc(shards, hasher[K](), opts...)
+}
+
+// NewShardedMapFunc creates the empty ShardedMap[K, V] partitioning the keys by the hash function.
+// The strings and the integers are hashed efficiently by NewShardedMap, the hash is needed for the other key types,
+// which are hashed by their type and fmt's %v otherwise.
+func NewShardedMapFunc[K comparable, V any](shards int, hash func(K) uint64, opts ...Option[K, V]) ShardedMap[K, V] {
+	n := 1
+	for n < shards {
+		n <<= 1
+	}
+
+	s := ShardedMap[K, V]{
+		shards: make([]Map[K, V], n),
+		mask:   uint64(n - 1),
+		hash:   hash,
+	}
+
+	for i := range s.shards {
+		s.shards[i] = New(opts...)
+	}
+
+	return s
+}
+
+// Add adds the element to ShardedMap[K, V].
+func (s ShardedMap[K, V]) Add(k K, v V) ShardedMap[K, V] {
+	s.shard(k).Add(k, v)
+
+	return s
+}
+
+// TryAdd adds the element to ShardedMap[K, V] and return ErrQuotaExceeded if the quota of its shard rejects it.
+func (s ShardedMap[K, V]) TryAdd(k K, v V) error {
+	return s.shard(k).TryAdd(k, v)
+}
+
+// Get return the value by key.
+func (s ShardedMap[K, V]) Get(k K) (V, bool) {
+	return s.shard(k).Get(k)
+}
+
+// GetOrSet return the existing value by key and true, otherwise adds the provided value and return it with false.
+func (s ShardedMap[K, V]) GetOrSet(k K, v V) (V, bool) {
+	return s.shard(k).GetOrSet(k, v)
+}
+
+// GetOrCompute return the existing value by key, otherwise adds the value returned by fn and return it.
+func (s ShardedMap[K, V]) GetOrCompute(k K, fn func() V) V {
+	return s.shard(k).GetOrCompute(k, fn)
+}
+
+// Update applies fn to the current value by key and its existence flag and stores the result under the lock of the shard.
+func (s ShardedMap[K, V]) Update(k K, fn func(V, bool) V) V {
+	return s.shard(k).Update(k, fn)
+}
+
+// Swap stores the value by key and return the previous value and whether it existed.
+func (s ShardedMap[K, V]) Swap(k K, v V) (V, bool) {
+	return s.shard(k).Swap(k, v)
+}
+
+// Delete deletes the element by key.
+func (s ShardedMap[K, V]) Delete(k K) bool {
+	return s.shard(k).Delete(k)
+}
+
+// Pop deletes the element by key and return its value.
+func (s ShardedMap[K, V]) Pop(k K) (V, bool) {
+	return s.shard(k).Pop(k)
+}
+
+// Exists check if value by key exists in ShardedMap[K, V].
+func (s ShardedMap[K, V]) Exists(k K) bool {
+	return s.shard(k).Exists(k)
+}
+
+// Len return the number of elements of all the shards.
+func (s ShardedMap[K, V]) Len() int {
+	var n int
+	for _, shard := range s.shards {
+		shard.mutex.RLock()
+		n += len(shard.innerMap)
+		shard.mutex.RUnlock()
+	}
+
+	return n
+}
+
+// Clear deletes all the elements, clearing the shards one by one.
+func (s ShardedMap[K, V]) Clear() {
+	for _, shard := range s.shards {
+		shard.Clear()
+	}
+}
+
+// Range calls fn for each element until fn returns false, holding the read lock of one shard at a time.
+func (s ShardedMap[K, V]) Range(fn func(K, V) bool) {
+	proceed := true
+	for _, shard := range s.shards {
+		shard.Range(func(k K, v V) bool {
+			proceed = fn(k, v)
+			return proceed
+		})
+
+		if !proceed {
+			return
+		}
+	}
+}
+
+// Keys return the keys of all the shards in no particular order.
+func (s ShardedMap[K, V]) Keys() []K {
+	var keys []K
+	for _, shard := range s.shards {
+		keys = shard.AppendKeys(keys)
+	}
+
+	return keys
+}
+
+// Values return the values of all the shards in no particular order.
+func (s ShardedMap[K, V]) Values() []V {
+	var values []V
+	for _, shard := range s.shards {
+		values = shard.AppendValues(values)
+	}
+
+	return values
+}
+
+// Filter return the elements accepted by the filter as Map[K, V].
+func (s ShardedMap[K, V]) Filter(filter func(K, V) bool) Map[K, V] {
+	filtered := make(map[K]V)
+
+	s.Range(func(k K, v V) bool {
+		if filter(k, v) {
+			filtered[k] = v
+		}
+
+		return true
+	})
+
+	return newMap(filtered)
+}
+
+// MapCopy return the copy of all the shards as builtin map[K]V.
+func (s ShardedMap[K, V]) MapCopy() map[K]V {
+	copied := make(map[K]V)
+
+	s.Range(func(k K, v V) bool {
+		copied[k] = v
+		return true
+	})
+
+	return copied
+}
+
+// shard return the shard of the key. The key is hashed normalized, since all the shards share the options,
+// so the keys normalizing equally land in the same shard.
+func (s ShardedMap[K, V]) shard(k K) Map[K, V] {
+	return s.shards[s.hash(s.shards[0].key(k))&s.mask]
+}
+
+// hasher return the seeded hash function of K, specialized for the strings and the integers.
+// The other keys are hashed by their type and fmt's %v, so the distinct keys formatting equally collide under every seed.
+func hasher[K comparable]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+
+	return func(k K) uint64 {
+		switch t := any(k).(type) {
+		case string:
+			return maphash.String(seed, t)
+		case int:
+			return mix(uint64(t))
+		case int64:
+			return mix(uint64(t))
+		case int32:
+			return mix(uint64(t))
+		case uint:
+			return mix(uint64(t))
+		case uint64:
+			return mix(t)
+		case uint32:
+			return mix(uint64(t))
+		default:
+			// the type is a part of the input, so the equally formatted keys of the different types, e.g. in map[any]V, differ.
+			return maphash.String(seed, fmt.Sprintf("%T:%v", k, k))
+		}
+	}
+}
+
+// mix is the finalizer of splitmix64, which spreads the sequential integers over all the shards.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
@@ -0,0 +1,55 @@
+package gomap_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestShardedMap(t *testing.T) {
+	s := gomap.NewShardedMap[int, int](8)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			for i := 0; i < 100; i++ {
+				s.Add(g*100+i, i)
+			}
+		}(g)
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, 400, s.Len())
+	assert.Len(t, s.Keys(), 400)
+
+	v, exists := s.Get(105)
+	assert.True(t, exists)
+	assert.Equal(t, 5, v)
+
+	assert.True(t, s.Delete(105))
+	assert.False(t, s.Exists(105))
+}
+
+func TestShardedMapKeyNormalizer(t *testing.T) {
+	s := gomap.NewShardedMap(16, gomap.WithKeyNormalizer[string, int](strings.ToLower))
+
+	for i := 0; i < 20; i++ {
+		s.Add(fmt.Sprintf("Key%d", i), i)
+		s.Add(fmt.Sprintf("KEY%d", i), i)
+	}
+
+	assert.Equal(t, 20, s.Len())
+
+	v, exists := s.Get("kEy7")
+	assert.True(t, exists)
+	assert.Equal(t, 7, v)
+}
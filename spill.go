@@ -0,0 +1,330 @@
+package gomap
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SpillOption configures the SpillMap.
+type SpillOption[K comparable, V any] func(*SpillMap[K, V])
+
+// WithSpillCodec sets the Codec of the spilled values, JSONCodec by default.
+func WithSpillCodec[K comparable, V any](codec Codec[V]) SpillOption[K, V] {
+	return func(s *SpillMap[K, V]) {
+		s.codec = codec
+	}
+}
+
+// WithSpillKey sets the encoding of the keys in the Store. By default the string keys are stored as is
+// and the others by their type and fmt's %v, so the distinct keys of Map[any, V], e.g. 1 and "1", do not collide.
+func WithSpillKey[K comparable, V any](key func(K) string) SpillOption[K, V] {
+	return func(s *SpillMap[K, V]) {
+		s.key = key
+	}
+}
+
+// SpillMap is a concurrency safe map which keeps up to budget values in memory and moves the rest to the Store.
+// The spilled values are paged back into memory on access. Only the values are spilled, the keys always stay in memory.
+// The Store is called without the lock, so the in-memory reads are not blocked by its latency,
+// only the operations on the key being moved to or from the Store wait for it.
+type SpillMap[K comparable, V any] struct {
+	mutex   *sync.Mutex
+	memory  map[K]V
+	spilled map[K]struct{}
+	// busy holds the keys whose Store I/O is in flight, the channel is closed when it's done.
+	busy   map[K]chan struct{}
+	store  Store
+	budget int
+	codec  Codec[V]
+	key    func(K) string
+}
+
+// NewSpillMap creates the empty SpillMap[K, V] keeping up to budget values in memory.
+func NewSpillMap[K comparable, V any](store Store, budget int, opts ...SpillOption[K, V]) SpillMap[K, V] {
+	s := SpillMap[K, V]{
+		mutex:   &sync.Mutex{},
+		memory:  make(map[K]V),
+		spilled: make(map[K]struct{}),
+		busy:    make(map[K]chan struct{}),
+		store:   store,
+		budget:  budget,
+		codec:   JSONCodec[V]{},
+		key:     spillKey[K],
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	return s
+}
+
+// Add adds the element to SpillMap[K, V], spilling another one to the Store if the memory budget is exceeded.
+func (s SpillMap[K, V]) Add(ctx context.Context, k K, v V) error {
+	s.mutex.Lock()
+	s.wait(k)
+	_, spilled := s.spilled[k]
+
+	if !spilled {
+		s.memory[k] = v
+		victims := s.victims(k)
+		s.mutex.Unlock()
+
+		return s.spill(ctx, victims)
+	}
+
+	s.reserve(k)
+	s.mutex.Unlock()
+
+	err := s.store.Delete(ctx, s.key(k))
+
+	s.mutex.Lock()
+	if err == nil {
+		delete(s.spilled, k)
+		s.memory[k] = v
+	}
+	s.release(k)
+	victims := s.victims(k)
+	s.mutex.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("delete spilled %v: %w", k, err)
+	}
+
+	return s.spill(ctx, victims)
+}
+
+// Get return the value by key, paging it back into memory if it was spilled.
+func (s SpillMap[K, V]) Get(ctx context.Context, k K) (V, bool, error) {
+	var v V
+
+	s.mutex.Lock()
+	for {
+		if v, exists := s.memory[k]; exists {
+			s.mutex.Unlock()
+			return v, true, nil
+		}
+
+		if _, spilled := s.spilled[k]; !spilled {
+			s.mutex.Unlock()
+			return v, false, nil
+		}
+
+		if _, busy := s.busy[k]; !busy {
+			break
+		}
+
+		s.wait(k)
+	}
+
+	s.reserve(k)
+	s.mutex.Unlock()
+
+	v, exists, err := s.pageIn(ctx, k)
+
+	s.mutex.Lock()
+	if err == nil {
+		delete(s.spilled, k)
+		if exists {
+			s.memory[k] = v
+		}
+	}
+	s.release(k)
+	victims := s.victims(k)
+	s.mutex.Unlock()
+
+	if err != nil {
+		return v, exists, err
+	}
+
+	return v, exists, s.spill(ctx, victims)
+}
+
+// pageIn reads and deletes the spilled value from the Store.
+func (s SpillMap[K, V]) pageIn(ctx context.Context, k K) (V, bool, error) {
+	var v V
+
+	b, exists, err := s.store.Get(ctx, s.key(k))
+	if err != nil {
+		return v, false, fmt.Errorf("get spilled %v: %w", k, err)
+	}
+
+	if !exists {
+		return v, false, nil
+	}
+
+	if v, err = s.codec.Decode(b); err != nil {
+		return v, false, fmt.Errorf("decode spilled %v: %w", k, err)
+	}
+
+	if err := s.store.Delete(ctx, s.key(k)); err != nil {
+		return v, true, fmt.Errorf("delete spilled %v: %w", k, err)
+	}
+
+	return v, true, nil
+}
+
+// Delete deletes the element from memory or from the Store.
+func (s SpillMap[K, V]) Delete(ctx context.Context, k K) (bool, error) {
+	s.mutex.Lock()
+	s.wait(k)
+
+	if _, exists := s.memory[k]; exists {
+		delete(s.memory, k)
+		s.mutex.Unlock()
+
+		return true, nil
+	}
+
+	if _, spilled := s.spilled[k]; !spilled {
+		s.mutex.Unlock()
+		return false, nil
+	}
+
+	s.reserve(k)
+	s.mutex.Unlock()
+
+	err := s.store.Delete(ctx, s.key(k))
+
+	s.mutex.Lock()
+	if err == nil {
+		delete(s.spilled, k)
+	}
+	s.release(k)
+	s.mutex.Unlock()
+
+	if err != nil {
+		return false, fmt.Errorf("delete spilled %v: %w", k, err)
+	}
+
+	return true, nil
+}
+
+// Exists check if value by key exists in memory or in the Store.
+func (s SpillMap[K, V]) Exists(k K) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	_, inMemory := s.memory[k]
+	_, spilled := s.spilled[k]
+
+	return inMemory || spilled
+}
+
+// Len return the number of elements, both in memory and spilled.
+func (s SpillMap[K, V]) Len() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.memory) + len(s.spilled)
+}
+
+// Spilled return the number of elements moved to the Store.
+func (s SpillMap[K, V]) Spilled() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.spilled)
+}
+
+// wait blocks until the Store I/O of the key is done. The lock must be held, it's released while waiting.
+func (s SpillMap[K, V]) wait(k K) {
+	for {
+		done, busy := s.busy[k]
+		if !busy {
+			return
+		}
+
+		s.mutex.Unlock()
+		<-done
+		s.mutex.Lock()
+	}
+}
+
+// reserve marks the key busy for the Store I/O. The lock must be held.
+func (s SpillMap[K, V]) reserve(k K) {
+	s.busy[k] = make(chan struct{})
+}
+
+// release wakes up the operations waiting for the key. The lock must be held.
+func (s SpillMap[K, V]) release(k K) {
+	close(s.busy[k])
+	delete(s.busy, k)
+}
+
+// victims reserves the arbitrary elements except the keep one and the busy ones until the rest meets the memory budget.
+// The lock must be held. The reserved elements stay readable in memory until they are spilled.
+func (s SpillMap[K, V]) victims(keep K) map[K]V {
+	excess := len(s.memory) - s.budget
+	for k := range s.busy {
+		// the busy elements in memory are being spilled already.
+		if _, exists := s.memory[k]; exists {
+			excess--
+		}
+	}
+
+	victims := make(map[K]V)
+	for k, v := range s.memory {
+		if len(victims) >= excess {
+			break
+		}
+
+		if _, busy := s.busy[k]; busy || k == keep {
+			continue
+		}
+
+		s.reserve(k)
+		victims[k] = v
+	}
+
+	return victims
+}
+
+// spill moves the reserved victims to the Store. The victim failed to be spilled stays in memory.
+func (s SpillMap[K, V]) spill(ctx context.Context, victims map[K]V) error {
+	var firstErr error
+
+	for k, v := range victims {
+		err := firstErr
+		if err == nil {
+			err = s.spillOne(ctx, k, v)
+		}
+
+		s.mutex.Lock()
+		if err == nil {
+			delete(s.memory, k)
+			s.spilled[k] = struct{}{}
+		}
+		s.release(k)
+		s.mutex.Unlock()
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (s SpillMap[K, V]) spillOne(ctx context.Context, k K, v V) error {
+	b, err := s.codec.Encode(v)
+	if err != nil {
+		return fmt.Errorf("encode spilled %v: %w", k, err)
+	}
+
+	if err := s.store.Set(ctx, s.key(k), b); err != nil {
+		return fmt.Errorf("spill %v: %w", k, err)
+	}
+
+	return nil
+}
+
+// spillKey encodes the key like the hasher of ShardedMap: the strings as is, the others by their type and fmt's %v.
+func spillKey[K comparable](k K) string {
+	if str, ok := any(k).(string); ok {
+		return str
+	}
+
+	return fmt.Sprintf("%T:%v", k, k)
+}
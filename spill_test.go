@@ -0,0 +1,134 @@
+package gomap_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestSpillMap(t *testing.T) {
+	ctx := context.Background()
+	store := gomaptest.NewMemoryStore(0)
+	m := gomap.NewSpillMap[string, int](store, 2)
+
+	for i, k := range []string{"a", "b", "c", "d"} {
+		require.NoError(t, m.Add(ctx, k, i))
+	}
+
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, 2, m.Spilled())
+	assert.Equal(t, 2, store.Len())
+
+	for i, k := range []string{"a", "b", "c", "d"} {
+		v, ok, err := m.Get(ctx, k)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, i, v)
+	}
+
+	assert.Equal(t, 2, m.Spilled(), "the paged in value spills another one")
+
+	for _, k := range []string{"a", "b", "c", "d"} {
+		deleted, err := m.Delete(ctx, k)
+		require.NoError(t, err)
+		assert.True(t, deleted)
+	}
+
+	assert.Equal(t, 0, m.Len())
+	assert.Equal(t, 0, store.Len())
+
+	_, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSpillMapAddReplacesSpilledValue(t *testing.T) {
+	ctx := context.Background()
+	m := gomap.NewSpillMap[string, int](gomaptest.NewMemoryStore(0), 1)
+
+	require.NoError(t, m.Add(ctx, "a", 1))
+	require.NoError(t, m.Add(ctx, "b", 2))
+	require.NoError(t, m.Add(ctx, "a", 3))
+
+	v, _, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestSpillMapKeysOfDifferentTypes(t *testing.T) {
+	ctx := context.Background()
+	m := gomap.NewSpillMap[any, string](gomaptest.NewMemoryStore(0), 0)
+
+	require.NoError(t, m.Add(ctx, 1, "int"))
+	require.NoError(t, m.Add(ctx, "1", "string"))
+	// the added key is kept in memory, so both keys are spilled by the next one.
+	require.NoError(t, m.Add(ctx, 2, "next"))
+	assert.Equal(t, 2, m.Spilled())
+
+	v, _, err := m.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "int", v)
+
+	v, _, err = m.Get(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "string", v)
+}
+
+func TestSpillMapStoreErrorKeepsValue(t *testing.T) {
+	ctx := context.Background()
+	store := gomaptest.NewMemoryStore(0)
+	m := gomap.NewSpillMap[string, int](store, 1)
+
+	require.NoError(t, m.Add(ctx, "a", 1))
+
+	store.SetFaults(gomaptest.Faults{ErrorRate: 1})
+	assert.ErrorIs(t, m.Add(ctx, "b", 2), gomaptest.ErrInjected)
+
+	store.SetFaults(gomaptest.Faults{})
+	assert.Equal(t, 0, m.Spilled())
+
+	v, ok, err := m.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+}
+
+func TestSpillMapMemoryReadsDoNotWaitForStore(t *testing.T) {
+	ctx := context.Background()
+	store := gomaptest.NewMemoryStore(0)
+	m := gomap.NewSpillMap[string, int](store, 1)
+
+	require.NoError(t, m.Add(ctx, "a", 1))
+	require.NoError(t, m.Add(ctx, "b", 2))
+
+	store.SetFaults(gomaptest.Faults{Latency: 200 * time.Millisecond})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, _, _ = m.Get(ctx, "a")
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	start := time.Now()
+	v, ok, err := m.Get(ctx, "b")
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, v)
+	assert.Less(t, elapsed, 100*time.Millisecond)
+	assert.Equal(t, 2, m.Len())
+
+	wg.Wait()
+}
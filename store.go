@@ -0,0 +1,51 @@
+package gomap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Store is the external key-value storage, e.g. Redis or the embedded database, used by the store-backed maps.
+type Store interface {
+	// Get return the value by key and false if the key does not exist.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set stores the value by key.
+	Set(ctx context.Context, key string, value []byte) error
+	// Delete deletes the key, deleting the missing key is not an error.
+	Delete(ctx context.Context, key string) error
+	// Scan calls fn for each key with the prefix until fn returns false.
+	Scan(ctx context.Context, prefix string, fn func(key string) bool) error
+}
+
+// Namespaced wraps the Store so every key is transparently prefixed by "namespace:vVersion:",
+// which lets several maps share one keyspace and makes the version bump invalidate all the old keys at once.
+func Namespaced(store Store, namespace string, version int) Store {
+	return namespacedStore{
+		store:  store,
+		prefix: fmt.Sprintf("%s:v%d:", namespace, version),
+	}
+}
+
+type namespacedStore struct {
+	store  Store
+	prefix string
+}
+
+func (n namespacedStore) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return n.store.Get(ctx, n.prefix+key)
+}
+
+func (n namespacedStore) Set(ctx context.Context, key string, value []byte) error {
+	return n.store.Set(ctx, n.prefix+key, value)
+}
+
+func (n namespacedStore) Delete(ctx context.Context, key string) error {
+	return n.store.Delete(ctx, n.prefix+key)
+}
+
+func (n namespacedStore) Scan(ctx context.Context, prefix string, fn func(key string) bool) error {
+	return n.store.Scan(ctx, n.prefix+prefix, func(key string) bool {
+		return fn(strings.TrimPrefix(key, n.prefix))
+	})
+}
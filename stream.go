@@ -0,0 +1,150 @@
+package gomap
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+const streamChunkSize = 1000
+
+type streamOptions struct {
+	aead        cipher.AEAD
+	compression Compression
+}
+
+// StreamOption configures Export and Import. The same options must be used on both sides.
+type StreamOption func(*streamOptions)
+
+// WithAEAD encrypts and authenticates the stream with the caller provided AEAD, e.g. AES-GCM,
+// so the persisted elements containing tokens or personal data are never written in plaintext.
+func WithAEAD(aead cipher.AEAD) StreamOption {
+	return func(o *streamOptions) {
+		o.aead = aead
+	}
+}
+
+// WithCompression compresses the stream, before it's encrypted if WithAEAD is used too.
+func WithCompression(c Compression) StreamOption {
+	return func(o *streamOptions) {
+		o.compression = c
+	}
+}
+
+func newStreamOptions(opts []StreamOption) streamOptions {
+	var o streamOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Export writes the elements of Map[K, V] to w as JSON lines, each line holding a JSON array of up to 1000 entries.
+// The elements are snapshotted first, so the Map is not locked while writing.
+func (m Map[K, V]) Export(w io.Writer, opts ...StreamOption) error {
+	o := newStreamOptions(opts)
+	entries := m.ToSlice(nil)
+
+	var sealer *aeadWriter
+	if o.aead != nil {
+		sealer = newAEADWriter(w, o.aead)
+		w = sealer
+	}
+
+	var compressor io.WriteCloser
+	if o.compression != nil {
+		var err error
+		if compressor, err = o.compression.NewWriter(w); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+
+		w = compressor
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	for start := 0; start < len(entries); start += streamChunkSize {
+		end := start + streamChunkSize
+		if end > len(entries) {
+			end = len(entries)
+		}
+
+		if err := enc.Encode(entries[start:end]); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+
+	if err := bw.Flush(); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+
+	if compressor != nil {
+		if err := compressor.Close(); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+
+	if sealer != nil {
+		if err := sealer.Close(); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Import reads the elements written by Export from r and adds them to Map[K, V].
+// Each chunk is added under the single write lock, so the readers are never blocked for the whole stream.
+func (m Map[K, V]) Import(r io.Reader, opts ...StreamOption) error {
+	o := newStreamOptions(opts)
+
+	if o.aead != nil {
+		r = newAEADReader(r, o.aead)
+	}
+
+	if o.compression != nil {
+		decompressor, err := o.compression.NewReader(r)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+
+		defer decompressor.Close()
+
+		r = decompressor
+	}
+
+	dec := json.NewDecoder(r)
+
+	for {
+		var chunk []Entry[K, V]
+		if err := dec.Decode(&chunk); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return fmt.Errorf("import: %w", err)
+		}
+
+		if err := m.importChunk(chunk); err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+	}
+}
+
+func (m Map[K, V]) importChunk(chunk []Entry[K, V]) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, e := range chunk {
+		if err := m.store(e.Key, e.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
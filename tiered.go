@@ -0,0 +1,135 @@
+package gomap
+
+import "sync"
+
+// InlineSize is the maximal length of the value TieredBytesMap stores inline.
+const InlineSize = 64
+
+// maxPooledSize is the maximal capacity of the buffer TieredBytesMap returns to the pool,
+// so the single huge value does not pin its memory after it's deleted.
+const maxPooledSize = 64 * 1024
+
+type inlineValue struct {
+	n    uint8
+	data [InlineSize]byte
+}
+
+// TieredBytesMap is a concurrency safe map of byte slices, which stores the values up to InlineSize bytes inline,
+// in the pointer-free map values the garbage collector does not scan, and the larger values in the pooled buffers.
+// The stored values are copied on Add and on Get, so the caller never shares the memory with the map.
+// It's the separate type rather than the Option of Map[K, []byte], because Map keeps its elements in map[K]V
+// and the options cannot change how the values are laid out in memory.
+type TieredBytesMap[K comparable] struct {
+	mutex *sync.RWMutex
+	small map[K]inlineValue
+	large map[K]*[]byte
+	pool  *sync.Pool
+}
+
+// NewTieredBytesMap creates the empty TieredBytesMap[K].
+func NewTieredBytesMap[K comparable]() TieredBytesMap[K] {
+	return TieredBytesMap[K]{
+		mutex: &sync.RWMutex{},
+		small: make(map[K]inlineValue),
+		large: make(map[K]*[]byte),
+		pool:  &sync.Pool{},
+	}
+}
+
+// Add copies the value into TieredBytesMap[K].
+func (t TieredBytesMap[K]) Add(k K, v []byte) TieredBytesMap[K] {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.release(k)
+
+	if len(v) <= InlineSize {
+		inline := inlineValue{n: uint8(len(v))}
+		copy(inline.data[:], v)
+		t.small[k] = inline
+
+		return t
+	}
+
+	buf := t.acquire(len(v))
+	*buf = append((*buf)[:0], v...)
+	t.large[k] = buf
+
+	return t
+}
+
+// Get return the copy of the value by key.
+func (t TieredBytesMap[K]) Get(k K) ([]byte, bool) {
+	return t.AppendValue(nil, k)
+}
+
+// AppendValue appends the value by key to dst, which avoids the allocation when dst has enough capacity.
+func (t TieredBytesMap[K]) AppendValue(dst []byte, k K) ([]byte, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if inline, exists := t.small[k]; exists {
+		return append(dst, inline.data[:inline.n]...), true
+	}
+
+	if buf, exists := t.large[k]; exists {
+		return append(dst, *buf...), true
+	}
+
+	return dst, false
+}
+
+// Delete deletes the value by key, returning its buffer to the pool.
+func (t TieredBytesMap[K]) Delete(k K) bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.release(k)
+}
+
+// Exists check if value by key exists in TieredBytesMap[K].
+func (t TieredBytesMap[K]) Exists(k K) bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	_, small := t.small[k]
+	_, large := t.large[k]
+
+	return small || large
+}
+
+// Len return the number of stored values.
+func (t TieredBytesMap[K]) Len() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	return len(t.small) + len(t.large)
+}
+
+func (t TieredBytesMap[K]) release(k K) bool {
+	if _, exists := t.small[k]; exists {
+		delete(t.small, k)
+		return true
+	}
+
+	if buf, exists := t.large[k]; exists {
+		delete(t.large, k)
+		if cap(*buf) <= maxPooledSize {
+			t.pool.Put(buf)
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func (t TieredBytesMap[K]) acquire(size int) *[]byte {
+	if buf, ok := t.pool.Get().(*[]byte); ok && cap(*buf) >= size {
+		return buf
+	}
+
+	buf := make([]byte, 0, size)
+
+	return &buf
+}
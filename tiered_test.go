@@ -0,0 +1,134 @@
+package gomap_test
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestTieredBytesMap(t *testing.T) {
+	m := gomap.NewTieredBytesMap[string]()
+
+	small := []byte("small")
+	large := bytes.Repeat([]byte{'x'}, gomap.InlineSize+1)
+
+	m.Add("small", small).Add("large", large)
+	small[0] = 'S'
+
+	v, ok := m.Get("small")
+	require.True(t, ok)
+	assert.Equal(t, []byte("small"), v, "the value is copied on Add")
+
+	v, ok = m.Get("large")
+	require.True(t, ok)
+	assert.Equal(t, large, v)
+
+	m.Add("large", []byte("now small"))
+	v, _ = m.Get("large")
+	assert.Equal(t, []byte("now small"), v)
+	assert.Equal(t, 2, m.Len())
+
+	assert.True(t, m.Delete("small"))
+	assert.False(t, m.Exists("small"))
+	assert.Equal(t, 1, m.Len())
+}
+
+var benchSizes = []int{16, gomap.InlineSize, 256}
+
+func BenchmarkTieredBytesMapAdd(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			m, v := gomap.NewTieredBytesMap[int](), make([]byte, size)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				m.Add(i%1024, v)
+			}
+		})
+	}
+}
+
+func BenchmarkMapOfBytesAdd(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			m, v := gomap.New[int, []byte](), make([]byte, size)
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				// the copy keeps the comparison fair, TieredBytesMap copies the value too.
+				m.Add(i%1024, append([]byte(nil), v...))
+			}
+		})
+	}
+}
+
+func BenchmarkTieredBytesMapGet(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			m, dst := gomap.NewTieredBytesMap[int](), make([]byte, 0, size)
+			for i := 0; i < 1024; i++ {
+				m.Add(i, make([]byte, size))
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				dst, _ = m.AppendValue(dst[:0], i%1024)
+			}
+		})
+	}
+}
+
+func BenchmarkMapOfBytesGet(b *testing.B) {
+	for _, size := range benchSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			m, dst := gomap.New[int, []byte](), make([]byte, 0, size)
+			for i := 0; i < 1024; i++ {
+				m.Add(i, make([]byte, size))
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				v, _ := m.Get(i % 1024)
+				dst = append(dst[:0], v...)
+			}
+		})
+	}
+}
+
+// BenchmarkTieredBytesMapGC and BenchmarkMapOfBytesGC measure the garbage collection with the small values stored,
+// which is where the pointer-free inline values pay off.
+func BenchmarkTieredBytesMapGC(b *testing.B) {
+	m := gomap.NewTieredBytesMap[int]()
+	for i := 0; i < 1<<16; i++ {
+		m.Add(i, make([]byte, 16))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+
+	runtime.KeepAlive(m)
+}
+
+func BenchmarkMapOfBytesGC(b *testing.B) {
+	m := gomap.New[int, []byte]()
+	for i := 0; i < 1<<16; i++ {
+		m.Add(i, make([]byte, 16))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runtime.GC()
+	}
+
+	runtime.KeepAlive(m)
+}
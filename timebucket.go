@@ -0,0 +1,120 @@
+package gomap
+
+import (
+	"sort"
+	"time"
+)
+
+// TimeBucket is the values appended within one resolution interval starting at Start.
+type TimeBucket[V any] struct {
+	Start  time.Time
+	Values []V
+}
+
+type timeBucketOptions struct {
+	clock Clock
+}
+
+// TimeBucketOption configures the TimeBucketMap.
+type TimeBucketOption func(*timeBucketOptions)
+
+// WithTimeBucketClock sets the Clock used to drop the buckets out of retention, SystemClock by default.
+func WithTimeBucketClock(clock Clock) TimeBucketOption {
+	return func(o *timeBucketOptions) {
+		o.clock = clock
+	}
+}
+
+// TimeBucketMap is a concurrency safe time series, which groups the values into the buckets of the resolution
+// and drops the buckets older than the retention.
+type TimeBucketMap[V any] struct {
+	buckets    Map[time.Time, []V]
+	resolution time.Duration
+	retention  time.Duration
+	clock      Clock
+}
+
+// NewTimeBucketMap creates the empty TimeBucketMap[V]. Zero retention keeps the buckets forever.
+func NewTimeBucketMap[V any](resolution, retention time.Duration, opts ...TimeBucketOption) TimeBucketMap[V] {
+	o := timeBucketOptions{clock: SystemClock}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return TimeBucketMap[V]{
+		buckets:    From(map[time.Time][]V{}),
+		resolution: resolution,
+		retention:  retention,
+		clock:      o.clock,
+	}
+}
+
+// Append appends the value to the bucket of at. The values older than the retention are dropped.
+func (t TimeBucketMap[V]) Append(at time.Time, v V) TimeBucketMap[V] {
+	m := t.buckets
+	start := t.bucketOf(at)
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	t.expire()
+
+	if t.retention > 0 && start.Before(t.cutoff()) {
+		return t
+	}
+
+	_ = m.store(start, append(m.innerMap[start], v))
+
+	return t
+}
+
+// Window return the buckets within [from, to) sorted by time.
+func (t TimeBucketMap[V]) Window(from, to time.Time) []TimeBucket[V] {
+	m := t.buckets
+	from, to = t.bucketOf(from), to.UTC()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	t.expire()
+
+	var window []TimeBucket[V]
+	for start, values := range m.innerMap {
+		if !start.Before(from) && start.Before(to) {
+			window = append(window, TimeBucket[V]{Start: start, Values: append([]V(nil), values...)})
+		}
+	}
+
+	sort.Slice(window, func(i, j int) bool {
+		return window[i].Start.Before(window[j].Start)
+	})
+
+	return window
+}
+
+// Len return the number of buckets.
+func (t TimeBucketMap[V]) Len() int {
+	return t.buckets.Len()
+}
+
+func (t TimeBucketMap[V]) bucketOf(at time.Time) time.Time {
+	return at.UTC().Truncate(t.resolution)
+}
+
+func (t TimeBucketMap[V]) cutoff() time.Time {
+	return t.bucketOf(t.clock.Now().Add(-t.retention))
+}
+
+// expire drops the buckets out of the retention. The write lock must be held.
+func (t TimeBucketMap[V]) expire() {
+	if t.retention <= 0 {
+		return
+	}
+
+	cutoff := t.cutoff()
+	for start := range t.buckets.innerMap {
+		if start.Before(cutoff) {
+			t.buckets.remove(start)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package gomap_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+	"github.com/kafkiansky/gomap/gomaptest"
+)
+
+func TestTimeBucketMap(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tb := gomap.NewTimeBucketMap[int](time.Minute, 0)
+
+	tb.Append(start.Add(10*time.Second), 1).
+		Append(start.Add(50*time.Second), 2).
+		Append(start.Add(2*time.Minute), 3)
+
+	assert.Equal(t, 2, tb.Len())
+
+	window := tb.Window(start, start.Add(time.Hour))
+	require.Len(t, window, 2)
+	assert.Equal(t, start, window[0].Start)
+	assert.Equal(t, []int{1, 2}, window[0].Values)
+	assert.Equal(t, start.Add(2*time.Minute), window[1].Start)
+	assert.Equal(t, []int{3}, window[1].Values)
+}
+
+func TestTimeBucketMapWindowBounds(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tb := gomap.NewTimeBucketMap[int](time.Minute, 0)
+
+	for i := 0; i < 5; i++ {
+		tb.Append(start.Add(time.Duration(i)*time.Minute), i)
+	}
+
+	window := tb.Window(start.Add(90*time.Second), start.Add(3*time.Minute))
+	require.Len(t, window, 2)
+	assert.Equal(t, []int{1}, window[0].Values, "from is truncated to the bucket")
+	assert.Equal(t, []int{2}, window[1].Values, "to is exclusive")
+
+	window[0].Values[0] = 42
+	assert.Equal(t, []int{1}, tb.Window(start.Add(time.Minute), start.Add(2*time.Minute))[0].Values)
+}
+
+func TestTimeBucketMapRetention(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	clock := gomaptest.NewFakeClock(start)
+	tb := gomap.NewTimeBucketMap[int](time.Minute, 5*time.Minute, gomap.WithTimeBucketClock(clock))
+
+	tb.Append(start, 1)
+	tb.Append(start.Add(-10*time.Minute), 0)
+	assert.Equal(t, 1, tb.Len(), "the value older than the retention is dropped")
+
+	clock.Advance(3 * time.Minute)
+	tb.Append(clock.Now(), 2)
+	assert.Equal(t, 2, tb.Len())
+
+	clock.Advance(4 * time.Minute)
+	window := tb.Window(start, clock.Now().Add(time.Minute))
+	require.Len(t, window, 1)
+	assert.Equal(t, []int{2}, window[0].Values)
+	assert.Equal(t, 1, tb.Len())
+}
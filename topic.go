@@ -0,0 +1,92 @@
+package gomap
+
+import "sync"
+
+type subscriber[M any] struct {
+	ch   chan M
+	once sync.Once
+}
+
+// TopicMap is a concurrency safe in-memory pub/sub, which fans out the messages to the subscribers of the key.
+// The topics are created on the first subscription and removed with their last subscriber.
+type TopicMap[K comparable, M any] struct {
+	topics Map[K, map[*subscriber[M]]struct{}]
+	buffer int
+}
+
+// NewTopicMap creates the empty TopicMap[K, M], each subscription is buffered up to buffer messages.
+func NewTopicMap[K comparable, M any](buffer int) TopicMap[K, M] {
+	return TopicMap[K, M]{
+		topics: From(map[K]map[*subscriber[M]]struct{}{}),
+		buffer: buffer,
+	}
+}
+
+// Subscribe subscribes to the messages published by key. The cancel function unsubscribes and closes the channel.
+func (t TopicMap[K, M]) Subscribe(k K) (<-chan M, func()) {
+	sub := &subscriber[M]{ch: make(chan M, t.buffer)}
+	m := t.topics
+
+	m.mutex.Lock()
+	subs, exists := m.innerMap[k]
+	if !exists {
+		subs = make(map[*subscriber[M]]struct{})
+		m.innerMap[k] = subs
+	}
+	subs[sub] = struct{}{}
+	m.mutex.Unlock()
+
+	cancel := func() {
+		sub.once.Do(func() {
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+
+			if subs, exists := m.innerMap[k]; exists {
+				delete(subs, sub)
+
+				if len(subs) == 0 {
+					delete(m.innerMap, k)
+				}
+			}
+
+			close(sub.ch)
+		})
+	}
+
+	return sub.ch, cancel
+}
+
+// Publish sends the message to each subscriber of the key and return the number of subscribers received it.
+// The message is dropped for the subscribers whose buffer is full, so the slow subscriber never blocks the publisher.
+func (t TopicMap[K, M]) Publish(k K, msg M) int {
+	m := t.topics
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var delivered int
+	for sub := range m.innerMap[k] {
+		select {
+		case sub.ch <- msg:
+			delivered++
+		default:
+		}
+	}
+
+	return delivered
+}
+
+// Subscribers return the number of subscribers of the key.
+func (t TopicMap[K, M]) Subscribers(k K) int {
+	m := t.topics
+
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return len(m.innerMap[k])
+}
+
+// Topics return the number of topics with at least one subscriber.
+func (t TopicMap[K, M]) Topics() int {
+	return t.topics.Len()
+}
@@ -0,0 +1,56 @@
+package gomap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypeMismatchError is returned when the value stored by the key has the type other than requested.
+type TypeMismatchError struct {
+	Key      any
+	Expected reflect.Type
+	Actual   reflect.Type
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("gomap: value by key %v is %v, not %v", e.Key, e.Actual, e.Expected)
+}
+
+// Is reports ErrTypeMismatch as the target, so the error can be checked with errors.Is.
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch
+}
+
+// RegisterAs adds the value of type T to the heterogeneous Map[K, any].
+func RegisterAs[T any, K comparable](m Map[K, any], k K, v T) Map[K, any] {
+	return m.Add(k, v)
+}
+
+// GetAs return the value by key as T. The false is returned if the key does not exist,
+// the *TypeMismatchError if the stored value is not T. The nil value is T if T is an interface.
+func GetAs[T any, K comparable](m Map[K, any], k K) (T, bool, error) {
+	var t T
+
+	v, exists := m.Get(k)
+	if !exists {
+		return t, false, nil
+	}
+
+	expected := reflect.TypeOf((*T)(nil)).Elem()
+
+	// the nil value of the interface T, e.g. RegisterAs[error](m, k, nil), is stored as the nil any without the type.
+	if v == nil && expected.Kind() == reflect.Interface {
+		return t, true, nil
+	}
+
+	t, ok := v.(T)
+	if !ok {
+		return t, true, &TypeMismatchError{
+			Key:      k,
+			Expected: expected,
+			Actual:   reflect.TypeOf(v),
+		}
+	}
+
+	return t, true, nil
+}
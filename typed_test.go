@@ -0,0 +1,43 @@
+package gomap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kafkiansky/gomap"
+)
+
+func TestGetAs(t *testing.T) {
+	m := gomap.New[string, any]()
+	gomap.RegisterAs(m, "n", 1)
+
+	n, ok, err := gomap.GetAs[int](m, "n")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 1, n)
+
+	_, ok, err = gomap.GetAs[string](m, "n")
+	assert.True(t, ok)
+	assert.ErrorIs(t, err, gomap.ErrTypeMismatch)
+
+	_, ok, err = gomap.GetAs[int](m, "missing")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestGetAsNilInterface(t *testing.T) {
+	m := gomap.New[string, any]()
+	gomap.RegisterAs[error](m, "err", nil)
+
+	v, ok, err := gomap.GetAs[error](m, "err")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, v)
+
+	_, _, err = gomap.GetAs[int](m, "err")
+	var mismatch *gomap.TypeMismatchError
+	assert.True(t, errors.As(err, &mismatch))
+}
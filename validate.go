@@ -0,0 +1,25 @@
+package gomap
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate checks each element of Map[K, V] against every rule and return all the violations joined with errors.Join,
+// each one prefixed by its key. The elements are checked in key order, so the result is stable.
+func (m Map[K, V]) Validate(rules ...func(K, V) error) error {
+	entries := m.ToSlice(func(a, b Entry[K, V]) bool {
+		return lessAny(a.Key, b.Key)
+	})
+
+	var errs []error
+	for _, e := range entries {
+		for _, rule := range rules {
+			if err := rule(e.Key, e.Value); err != nil {
+				errs = append(errs, fmt.Errorf("key %v: %w", e.Key, err))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
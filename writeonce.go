@@ -0,0 +1,36 @@
+package gomap
+
+import (
+	"context"
+	"fmt"
+)
+
+// WriteOnceMap is a concurrency safe map where each key can be set exactly once, e.g. for the plugin registries.
+// The readers can wait until the key is set with GetWait.
+type WriteOnceMap[K comparable, V any] struct {
+	futures FutureMap[K, V]
+}
+
+// NewWriteOnceMap creates the empty WriteOnceMap[K, V].
+func NewWriteOnceMap[K comparable, V any]() WriteOnceMap[K, V] {
+	return WriteOnceMap[K, V]{futures: NewFutureMap[K, V]()}
+}
+
+// Add sets the value by key. ErrKeyExists is returned if the key is already set.
+func (w WriteOnceMap[K, V]) Add(k K, v V) error {
+	if !w.futures.set(k, v, true) {
+		return fmt.Errorf("add %v: %w", k, ErrKeyExists)
+	}
+
+	return nil
+}
+
+// Get return the value by key without waiting.
+func (w WriteOnceMap[K, V]) Get(k K) (V, bool) {
+	return w.futures.Get(k)
+}
+
+// GetWait return the value by key, blocking until the key is set or the ctx is done.
+func (w WriteOnceMap[K, V]) GetWait(ctx context.Context, k K) (V, error) {
+	return w.futures.GetWait(ctx, k)
+}